@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/microhod/adventofcode/internal/encoding/csv"
+	"github.com/microhod/adventofcode/internal/ocr"
 	"github.com/microhod/adventofcode/internal/puzzle"
 )
 
@@ -22,7 +23,7 @@ const (
 )
 
 func main() {
-	puzzle.NewSolution("Transparent Origami", part1, part2).Run()
+	puzzle.NewSolution("Transparent Origami", part1, puzzle.Answer(part2, InputFile)).Run()
 }
 
 func part1() error {
@@ -44,10 +45,10 @@ func part1() error {
 	return nil
 }
 
-func part2() error {
-	man, err := readManual(InputFile)
+func part2(path string) (string, error) {
+	man, err := readManual(path)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	for _, fold := range man.Folds {
@@ -56,7 +57,15 @@ func part2() error {
 
 	fmt.Println(man)
 
-	return nil
+	var pixels [][]bool
+	for _, row := range man.Dots {
+		var pixelRow []bool
+		for _, dot := range row {
+			pixelRow = append(pixelRow, dot == 1)
+		}
+		pixels = append(pixels, pixelRow)
+	}
+	return ocr.Read(pixels)
 }
 
 func readManual(path string) (*Manual, error) {
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/microhod/adventofcode/internal/file"
+	"github.com/microhod/adventofcode/internal/ocr"
 	"github.com/microhod/adventofcode/internal/puzzle"
 )
 
@@ -15,7 +16,7 @@ const (
 )
 
 func main() {
-	puzzle.NewSolution("CathodeRay Tube", part1, part2).Run()
+	puzzle.NewSolution("CathodeRay Tube", part1, puzzle.Answer(part2, InputFile)).Run()
 }
 
 func part1() error {
@@ -41,10 +42,10 @@ func part1() error {
 	return nil
 }
 
-func part2() error {
-	instructions, err := parse(InputFile)
+func part2(path string) (string, error) {
+	instructions, err := parse(path)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	cpu := &CPU{RegisterX: 1}
@@ -66,7 +67,8 @@ func part2() error {
 	}
 
 	fmt.Println(crt.Render())
-	return nil
+
+	return ocr.ReadString(crt.Render())
 }
 
 func parse(path string) ([]Instruction, error) {
@@ -2,8 +2,6 @@ package main
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 	"sync"
 
 	"github.com/microhod/adventofcode/internal/file"
@@ -109,35 +107,21 @@ func part2() error {
 }
 
 func parse(path string) (Tunnels, error) {
-	input, err := file.Read(path)
+	scans, err := file.DecodeLines[Scan](path,
+		"Valve {label:word} has flow rate={rate:int}; {_:string} {to:[]word}")
 	if err != nil {
 		return Tunnels{}, err
 	}
 
-	input = strings.ReplaceAll(input, "Valve ", "")
-	input = strings.ReplaceAll(input, " has flow rate=", ", ")
-	input = strings.ReplaceAll(input, "; tunnels lead to valves ", ", ")
-	input = strings.ReplaceAll(input, "; tunnel leads to valve ", ", ")
-
 	valves := map[string]Valve{}
 	neighbours := map[string][]string{}
 
-	for _, line := range strings.Split(input, "\n") {
-		if line == "" {
-			continue
-		}
-		parts := strings.Split(line, ", ")
-
-		valve := parts[0]
-		flowRate, err := strconv.Atoi(parts[1])
-		if err != nil {
-			return Tunnels{}, err
+	for _, scan := range scans {
+		valves[scan.Label] = Valve{
+			Label:    scan.Label,
+			FlowRate: scan.Rate,
 		}
-		valves[valve] = Valve{
-			Label:    valve,
-			FlowRate: flowRate,
-		}
-		neighbours[valve] = parts[2:]
+		neighbours[scan.Label] = scan.To
 	}
 
 	tunnels := Tunnels{Graph: graph.NewGraph[Valve]()}
@@ -152,6 +136,13 @@ func parse(path string) (Tunnels, error) {
 	return tunnels, nil
 }
 
+// Scan is a line of the input
+type Scan struct {
+	Label string
+	Rate  int
+	To    []string
+}
+
 type Valve struct {
 	Label    string
 	FlowRate int
@@ -3,7 +3,6 @@ package main
 import (
 	"fmt"
 	"slices"
-	"strings"
 
 	"github.com/microhod/adventofcode/internal/encoding/csv"
 	"github.com/microhod/adventofcode/internal/file"
@@ -56,41 +55,28 @@ func part2() error {
 }
 
 func parse(path string) (Rules, []Update, error) {
-	input, err := file.Read(path)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	parts := strings.Split(input, "\n\n")
-
 	rules := make(Rules)
-	for _, line := range strings.Split(parts[0], "\n") {
-		if line == "" {
-			continue
-		}
-		nums, err := csv.ParseInts(line, "|")
-		if err != nil {
-			return nil, nil, err
-		}
+	var updates []Update
 
-		if rules[nums[1]] == nil {
-			rules[nums[1]] = set.NewSet[int]()
-		}
-		rules[nums[1]].Add(nums[0])
-	}
+	_, err := file.ReadSections(path,
+		file.EachLine(func(line string) error {
+			nums, err := csv.ParseInts(line, "|")
+			if err != nil {
+				return err
+			}
 
-	var updates []Update
-	for _, line := range strings.Split(parts[1], "\n") {
-		if line == "" {
-			continue
-		}
-		nums, err := csv.ParseInts(line)
-		if err != nil {
-			return nil, nil, err
-		}
-		updates = append(updates, Update(nums))
-	}
-	return rules, updates, nil
+			if rules[nums[1]] == nil {
+				rules[nums[1]] = set.NewSet[int]()
+			}
+			rules[nums[1]].Add(nums[0])
+			return nil
+		}),
+		file.ParseLines(&updates, func(line string) (Update, error) {
+			nums, err := csv.ParseInts(line)
+			return Update(nums), err
+		}),
+	)
+	return rules, updates, err
 }
 
 type Rules map[int]set.Set[int]
@@ -2,11 +2,11 @@ package main
 
 import (
 	"fmt"
-	"regexp"
-	"strconv"
 	"strings"
 
+	"github.com/microhod/adventofcode/internal/encoding/csv"
 	"github.com/microhod/adventofcode/internal/file"
+	"github.com/microhod/adventofcode/internal/maths"
 	"github.com/microhod/adventofcode/internal/puzzle"
 )
 
@@ -28,7 +28,7 @@ func part1() error {
 	var total int64
 	for i := range machines {
 		solution := machines[i].Solve()
-		total += 3*solution[0] + solution[1]
+		total = maths.MustSumInt64(total, maths.MustMulInt64(3, solution[0]), solution[1])
 	}
 	fmt.Printf("fewest tokens to win all prizes: %d\n", total)
 	return nil
@@ -46,7 +46,7 @@ func part2() error {
 		machines[i].Prize.Y += 10000000000000
 
 		solution := machines[i].Solve()
-		total += 3*solution[0] + solution[1]
+		total = maths.MustSumInt64(total, maths.MustMulInt64(3, solution[0]), solution[1])
 	}
 	fmt.Printf("fewest tokens to win all prizes: %d\n", total)
 	return nil
@@ -62,19 +62,15 @@ func parse(path string) ([]ClawMachine, error) {
 
 	var machines []ClawMachine
 	for _, claw := range claws {
-		nums := regexp.MustCompile(`\d+`).FindAllString(claw, 6)
-		parse := func(s string) int64 {
-			n, err := strconv.ParseInt(s, 10, 64)
-			if err != nil {
-				panic(err)
-			}
-			return n
+		nums, err := csv.ExtractN[int64](claw, 6)
+		if err != nil {
+			return nil, err
 		}
 
 		machines = append(machines, ClawMachine{
-			A:     Vector64{X: parse(nums[0]), Y: parse(nums[1])},
-			B:     Vector64{X: parse(nums[2]), Y: parse(nums[3])},
-			Prize: Vector64{X: parse(nums[4]), Y: parse(nums[5])},
+			A:     Vector64{X: nums[0], Y: nums[1]},
+			B:     Vector64{X: nums[2], Y: nums[3]},
+			Prize: Vector64{X: nums[4], Y: nums[5]},
 		})
 	}
 	return machines, nil
@@ -84,12 +80,16 @@ type ClawMachine struct {
 	A, B, Prize Vector64
 }
 
+// Solve works out how many times to press A and B, the prizes in part 2 are
+// big enough that the products could overflow so it panics rather than
+// returning a wrong answer
 func (m ClawMachine) Solve() [2]int64 {
+	mul, sub := maths.MustMulInt64, maths.MustSubInt64
+
 	// https://en.wikipedia.org/wiki/Cramer%27s_rule
-	a := (m.Prize.X * m.B.Y - m.Prize.Y * m.B.X) /
-	(m.A.X * m.B.Y - m.A.Y * m.B.X)
-	b := (m.Prize.Y * m.A.X - m.Prize.X * m.A.Y) /
-	(m.B.Y * m.A.X - m.B.X * m.A.Y)
+	det := sub(mul(m.A.X, m.B.Y), mul(m.A.Y, m.B.X))
+	a := sub(mul(m.Prize.X, m.B.Y), mul(m.Prize.Y, m.B.X)) / det
+	b := sub(mul(m.Prize.Y, m.A.X), mul(m.Prize.X, m.A.Y)) / det
 
 	if m.run([2]int64{a, b}) != m.Prize {
 		return [2]int64{}
@@ -99,12 +99,15 @@ func (m ClawMachine) Solve() [2]int64 {
 }
 
 func (m ClawMachine) run(ab [2]int64) Vector64 {
+	add, mul := maths.MustAddInt64, maths.MustMulInt64
 	return Vector64{
-		X: ab[0]*m.A.X + ab[1]*m.B.X,
-		Y: ab[0]*m.A.Y + ab[1]*m.B.Y,
+		X: add(mul(ab[0], m.A.X), mul(ab[1], m.B.X)),
+		Y: add(mul(ab[0], m.A.Y), mul(ab[1], m.B.Y)),
 	}
 }
 
+// Vector64 is plane.Vector with int64s, since an int is only guaranteed to be
+// 32 bits and the prizes in part 2 need more
 type Vector64 struct {
 	X, Y int64
 }
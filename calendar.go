@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mgutz/ansi"
+	"github.com/microhod/adventofcode/internal/puzzle"
+)
+
+var locked = ansi.ColorFunc("black+h")
+
+// calendar prints the calendar art for a year, coloured in as on the site
+//
+// aoc calendar YEAR
+func calendar(args []string) error {
+	year, err := parseYear(args)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	cal, err := client.Calendar(year)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(renderCalendar(cal))
+	fmt.Printf("\n%s %d/%d\n", goldStar, cal.TotalStars(), 2*puzzle.Days(year))
+	return nil
+}
+
+func renderCalendar(cal *puzzle.Calendar) string {
+	var lines []string
+	for _, line := range cal.Lines {
+		builder := new(strings.Builder)
+		for _, span := range line {
+			switch {
+			// art for days without stars is greyed out
+			case span.Day > 0 && cal.Stars[span.Day] == 0:
+				builder.WriteString(locked(span.Text))
+			case span.Colour != "":
+				builder.WriteString(ansi.Color(span.Text, xterm256(span.Colour)))
+			default:
+				builder.WriteString(span.Text)
+			}
+		}
+		lines = append(lines, builder.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// xterm256 converts a css hex colour e.g. #ff0 or #ffff66 to the closest
+// colour in the 6x6x6 cube of the xterm 256 colour palette
+func xterm256(hex string) string {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return ""
+	}
+
+	cube := func(c uint64) uint64 {
+		return (c*5 + 127) / 255
+	}
+	r, g, b := cube(rgb>>16&0xff), cube(rgb>>8&0xff), cube(rgb&0xff)
+	return fmt.Sprint(16 + 36*r + 6*g + b)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/microhod/adventofcode/internal/puzzle"
+)
+
+// fetch gets the puzzle files for the year & day specified, the solution is
+// created from one of the templates e.g. templates/grid.go.tmpl
+//
+// aoc fetch [-template simple|grid|graph] YEAR DAY
+func fetch(args []string) error {
+	flags := flag.NewFlagSet("fetch", flag.ExitOnError)
+	templateName := flags.String("template", "", "the template to create main.go from, by default this is guessed from the input")
+
+	year, day, err := parseYearDay(parseFlags(flags, args))
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	p, err := client.Get(year, day)
+	if err != nil {
+		return err
+	}
+
+	// make folders
+	err = os.MkdirAll(folder(year, day), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	// README.md
+	readme, err := os.Create(fmt.Sprintf("%s/%s", folder(year, day), readmeFile))
+	if err != nil {
+		return err
+	}
+	defer readme.Close()
+	fmt.Fprintln(readme, p.Readme)
+
+	// input.txt, encrypted as input.txt.enc if there's a key configured
+	err = writeInput(fmt.Sprintf("%s/%s", folder(year, day), inputFile), []byte(p.Input))
+	if err != nil {
+		return err
+	}
+
+	data := puzzle.NewTemplateData(p)
+	fmt.Printf("input is %s\n", data.Shape)
+	if *templateName == "" {
+		*templateName = data.Shape.Template()
+	}
+
+	// test.txt, plus test2.txt if part 2 has a different example
+	for i, example := range data.Examples {
+		data.Examples[i].File = testFile
+		if i > 0 && example.Input != data.Examples[0].Input {
+			data.Examples[i].File = fmt.Sprintf("test%d.txt", example.Part)
+		}
+
+		testFilePath := fmt.Sprintf("%s/%s", folder(year, day), data.Examples[i].File)
+		// only create test files if they don't already exist
+		if !exists(testFilePath) {
+			err := os.WriteFile(testFilePath, []byte(example.Input+"\n"), 0644)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// main.go
+	err = writeTemplate(fmt.Sprintf("%s/%s", folder(year, day), solutionFile), *templateName, data)
+	if err != nil {
+		return err
+	}
+
+	// main_test.go
+	return writeTemplate(fmt.Sprintf("%s/%s", folder(year, day), testSolutionFile), testTemplate, data)
+}
+
+// writeTemplate creates the file at path from the template, only if the file
+// doesn't already exist
+func writeTemplate(path, name string, data puzzle.TemplateData) error {
+	if exists(path) {
+		return nil
+	}
+
+	filesystems, err := templateFilesystems()
+	if err != nil {
+		return err
+	}
+	tmpl, err := puzzle.LoadTemplate(name, filesystems...)
+	if err != nil {
+		return err
+	}
+	content, err := puzzle.InitialSolutionFile(tmpl, data)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0644)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/microhod/adventofcode/internal/index"
+)
+
+const module = "github.com/microhod/adventofcode"
+
+// tooling are the internal packages used by aoc itself rather than shared
+// between solutions
+var tooling = []string{"christmas", "config", "index", "leaderboard", "markdown", "puzzle", "search", "secret", "site", "visual"}
+
+// index reports which internal packages each solution uses, and which types
+// and functions are written again and again and could move into internal
+//
+// aoc index [-top N] [-min DAYS] [YEAR]
+func indexSolutions(args []string) error {
+	flags := flag.NewFlagSet("index", flag.ExitOnError)
+	top := flags.Int("top", 20, "the number of most used internal functions and types to show")
+	minDays := flags.Int("min", 3, "only suggest local types and functions defined in at least this many days")
+	positional := parseFlags(flags, args)
+
+	all, err := years()
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		year, err := parseYear(positional)
+		if err != nil {
+			return err
+		}
+		all = []int{year}
+	}
+
+	var days []index.Day
+	for _, year := range all {
+		for _, day := range solvedDays(year) {
+			days = append(days, index.Day{Year: year, Day: day, Dir: folder(year, day)})
+		}
+	}
+
+	report, err := index.Analyse(module, ".", days, tooling...)
+	if err != nil {
+		return err
+	}
+	for day, err := range report.Errors {
+		fmt.Fprintf(os.Stderr, "WARNING: skipping %s: %s\n", day.Dir, err)
+	}
+
+	section := func(header string, rows [][]string) error {
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "%s\t\n", header)
+		for _, row := range rows {
+			fmt.Fprintf(tw, "%s\t\n", strings.Join(row, "\t"))
+		}
+		fmt.Fprintln(tw)
+		return tw.Flush()
+	}
+	byName := func(a, b string) bool { return a < b }
+
+	var rows [][]string
+	for _, pkg := range index.Sorted(report.Packages, byName) {
+		rows = append(rows, []string{pkg, fmt.Sprint(len(report.Packages[pkg]))})
+	}
+	if err := section("package\tdays", rows); err != nil {
+		return err
+	}
+
+	rows = nil
+	objects := index.Sorted(report.Objects, byName)
+	for _, object := range objects[:min(*top, len(objects))] {
+		rows = append(rows, []string{object, fmt.Sprint(len(report.Objects[object]))})
+	}
+	if err := section("used\tdays", rows); err != nil {
+		return err
+	}
+
+	rows = nil
+	for _, duplicate := range report.Duplicates {
+		shared := duplicate.Shared
+		if duplicate.Identical {
+			shared += " (identical)"
+		}
+		rows = append(rows, []string{duplicate.Day.Dir, duplicate.Local, shared})
+	}
+	if err := section("day\tduplicate type\tshared type", rows); err != nil {
+		return err
+	}
+
+	rows = nil
+	for _, local := range index.Sorted(report.Locals, func(a, b index.Local) bool { return a.Name < b.Name }) {
+		if len(report.Locals[local]) < *minDays {
+			break
+		}
+		var dirs []string
+		for _, day := range report.Locals[local] {
+			dirs = append(dirs, day.Dir)
+		}
+		rows = append(rows, []string{local.Kind + " " + local.Name, fmt.Sprint(len(dirs)), strings.Join(dirs, " ")})
+	}
+	return section("promotion candidate\tdays\t", rows)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/microhod/adventofcode/internal/config"
+	"github.com/microhod/adventofcode/internal/secret"
+)
+
+// inputs manages the encrypted copies of puzzle inputs, so that the plain
+// text inputs don't need to be committed
+//
+// input.txt is ignored, but inputs committed before that are still tracked
+// and need encrypting and then removing with git rm --cached, see the README
+//
+// aoc inputs encrypt [-keep] [YEAR]
+// aoc inputs decrypt [YEAR]
+// aoc inputs status [YEAR]
+// aoc inputs key
+func inputs(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("need one of encrypt, decrypt, status or key")
+	}
+
+	flags := flag.NewFlagSet("inputs "+args[0], flag.ExitOnError)
+	keep := flags.Bool("keep", false, "keep the plain text input after encrypting")
+	positional := parseFlags(flags, args[1:])
+
+	var paths []string
+	if args[0] != "key" {
+		var err error
+		if paths, err = inputPaths(positional); err != nil {
+			return err
+		}
+	}
+
+	switch args[0] {
+	case "encrypt":
+		return encryptInputs(paths, *keep)
+	case "decrypt":
+		return decryptInputs(paths)
+	case "status":
+		return inputStatus(paths)
+	case "key":
+		key, err := secret.NewKey()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("input_key: %s\n", key)
+		fmt.Fprintf(os.Stderr, "\nadd this to %s or set %s\n", config.Path(), config.InputKeyEnv)
+		return nil
+	default:
+		return fmt.Errorf("unknown inputs command: %s", args[0])
+	}
+}
+
+// writeInput encrypts the input if a key is configured, otherwise it is
+// written in plain text
+func writeInput(path string, input []byte) error {
+	err := secret.WriteFile(path, input)
+	if errors.Is(err, secret.ErrNoKey) {
+		fmt.Fprintf(os.Stderr, "WARNING: %s, writing %s in plain text\n", err, path)
+		return os.WriteFile(path, input, 0644)
+	}
+	return err
+}
+
+func encryptInputs(paths []string, keep bool) error {
+	for _, path := range paths {
+		if !exists(path) {
+			continue
+		}
+
+		input, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := secret.WriteFile(path, input); err != nil {
+			return err
+		}
+		fmt.Printf("encrypted %s\n", path)
+
+		if !keep {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func decryptInputs(paths []string) error {
+	for _, path := range paths {
+		if exists(path) || !exists(path+secret.Extension) {
+			continue
+		}
+
+		input, err := secret.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, input, 0644); err != nil {
+			return err
+		}
+		fmt.Printf("decrypted %s\n", path)
+	}
+	return nil
+}
+
+func inputStatus(paths []string) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "input\tplain\tencrypted\t")
+
+	for _, path := range paths {
+		plain, encrypted := "-", "-"
+		if exists(path) {
+			plain = "yes"
+		}
+		if exists(path + secret.Extension) {
+			// check the input can actually be decrypted with the current key
+			encrypted = "yes"
+			if _, err := secret.ReadFile(path); err != nil {
+				encrypted = err.Error()
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t\n", path, plain, encrypted)
+	}
+	return tw.Flush()
+}
+
+// inputPaths returns the path to the input for every day folder, optionally
+// restricted to a single year
+func inputPaths(args []string) ([]string, error) {
+	all, err := years()
+	if err != nil {
+		return nil, err
+	}
+	if len(args) > 0 {
+		year, err := parseYear(args)
+		if err != nil {
+			return nil, err
+		}
+		all = []int{year}
+	}
+
+	var paths []string
+	for _, year := range all {
+		for _, day := range solvedDays(year) {
+			paths = append(paths, filepath.Join(folder(year, day), inputFile))
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,57 @@
+// Package config loads the user's settings, which live outside the repo so
+// that secrets aren't committed
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// PathEnv overrides the location of the config file
+	PathEnv = "AOC_CONFIG"
+	// InputKeyEnv overrides the input key in the config file
+	InputKeyEnv = "AOC_INPUT_KEY"
+)
+
+type Config struct {
+	// InputKey is the hex encoded AES key used to encrypt puzzle inputs
+	InputKey string `yaml:"input_key"`
+	// Templates is a folder of solution templates, which take precedence over
+	// the ones in the repo
+	Templates string `yaml:"templates"`
+}
+
+// Path returns the location of the config file e.g. ~/.config/adventofcode/config.yaml
+func Path() string {
+	if path := os.Getenv(PathEnv); path != "" {
+		return path
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = "."
+	}
+	return filepath.Join(dir, "adventofcode", "config.yaml")
+}
+
+// Load reads the config file, a missing file is treated as an empty config
+func Load() (*Config, error) {
+	config := new(Config)
+
+	bytes, err := os.ReadFile(Path())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(bytes, config); err != nil {
+		return nil, err
+	}
+
+	if key := os.Getenv(InputKeyEnv); key != "" {
+		config.InputKey = key
+	}
+	return config, nil
+}
@@ -2,6 +2,8 @@
 package csv
 
 import (
+	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 )
@@ -51,3 +53,27 @@ func ParseInt64s(str string, separator ...string) ([]int64, error) {
 
 	return nums, nil
 }
+
+// ParseBigInts is ParseInts for numbers which don't fit in an int64
+func ParseBigInts(str string, separator ...string) ([]*big.Int, error) {
+	if len(separator) < 1 {
+		separator = []string{","}
+	}
+
+	var nums []*big.Int
+	for _, s := range strings.Split(str, separator[0]) {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		n, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer: %q", s)
+		}
+
+		nums = append(nums, n)
+	}
+
+	return nums, nil
+}
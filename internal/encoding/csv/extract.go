@@ -0,0 +1,137 @@
+package csv
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ExtractInts finds every integer in str whatever is around it, e.g.
+// "Sensor at x=-2, y=15" is [-2 15]
+//
+// a '-' or '+' is only a sign when it isn't straight after a digit, so ranges
+// like "2-4" are [2 4] rather than [2 -4]
+func ExtractInts[T constraints.Signed](str string) ([]T, error) {
+	var nums []T
+	for _, s := range numbers(str, true) {
+		n, err := strconv.ParseInt(s, 10, bitSize[T]())
+		if err != nil {
+			return nil, err
+		}
+		nums = append(nums, T(n))
+	}
+	return nums, nil
+}
+
+func ExtractInts64(str string) ([]int64, error) {
+	return ExtractInts[int64](str)
+}
+
+// ExtractUints finds every run of digits in str, ignoring any signs
+func ExtractUints[T constraints.Unsigned](str string) ([]T, error) {
+	var nums []T
+	for _, s := range numbers(str, false) {
+		n, err := strconv.ParseUint(s, 10, bitSize[T]())
+		if err != nil {
+			return nil, err
+		}
+		nums = append(nums, T(n))
+	}
+	return nums, nil
+}
+
+// ExtractN is ExtractInts for when there should be exactly n integers e.g. the
+// 6 in each claw machine of 2024/13
+func ExtractN[T constraints.Signed](str string, n int) ([]T, error) {
+	nums, err := ExtractInts[T](str)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCount(str, nums, n); err != nil {
+		return nil, err
+	}
+	return nums, nil
+}
+
+// ExtractNUints is ExtractUints for when there should be exactly n integers
+func ExtractNUints[T constraints.Unsigned](str string, n int) ([]T, error) {
+	nums, err := ExtractUints[T](str)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCount(str, nums, n); err != nil {
+		return nil, err
+	}
+	return nums, nil
+}
+
+func checkCount[T constraints.Integer](str string, nums []T, n int) error {
+	if len(nums) != n {
+		return fmt.Errorf("expected %d integers but found %d in: %q", n, len(nums), str)
+	}
+	return nil
+}
+
+// SplitInts is ParseInts which splits on any of the separators, e.g. for
+// "1,2;3" with "," and ";"
+func SplitInts[T constraints.Signed](str string, separators ...string) ([]T, error) {
+	if len(separators) < 1 {
+		separators = []string{","}
+	}
+
+	fields := []string{str}
+	for _, separator := range separators {
+		var split []string
+		for _, field := range fields {
+			split = append(split, strings.Split(field, separator)...)
+		}
+		fields = split
+	}
+
+	var nums []T
+	for _, s := range fields {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		n, err := strconv.ParseInt(s, 10, bitSize[T]())
+		if err != nil {
+			return nil, err
+		}
+		nums = append(nums, T(n))
+	}
+	return nums, nil
+}
+
+// numbers finds the runs of digits in str, including their sign if signed
+func numbers(str string, signed bool) []string {
+	isDigit := func(i int) bool {
+		return 0 <= i && i < len(str) && '0' <= str[i] && str[i] <= '9'
+	}
+
+	var nums []string
+	for i := 0; i < len(str); i++ {
+		start := i
+		sign := signed && (str[i] == '-' || str[i] == '+') && isDigit(i+1) && !isDigit(i-1)
+		if !sign && !isDigit(i) {
+			continue
+		}
+		if sign {
+			i++
+		}
+		for isDigit(i) {
+			i++
+		}
+		nums = append(nums, str[start:i])
+	}
+	return nums
+}
+
+// bitSize is the size of T for strconv
+func bitSize[T constraints.Integer]() int {
+	return reflect.TypeFor[T]().Bits()
+}
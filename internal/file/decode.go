@@ -0,0 +1,219 @@
+package file
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// placeholders are the types which can be used in a DecodeLines pattern, and
+// what they match
+var placeholders = map[string]string{
+	"int":    `[-+]?\d+`,
+	"word":   `\w+`,
+	"string": `.*?`,
+	"[]int":  `[-+]?\d+(?:,\s*[-+]?\d+)*`,
+	"[]word": `\w+(?:,\s*\w+)*`,
+}
+
+var listSeparator = regexp.MustCompile(`,\s*`)
+
+type placeholder struct {
+	name, kind string
+}
+
+// DecodeLines decodes each line of the file at path into a struct by matching
+// it against pattern, which is literal text with typed placeholders e.g.
+//
+//	Valve {label:word} has flow rate={rate:int}; {_:string} {to:[]word}
+//
+// the types are int, word, string (as little as possible), and lists of ints
+// or words separated by commas. A placeholder fills the field with a matching
+// `decode:"name"` tag, or the field with the same name ignoring case, and
+// placeholders named _ are matched but thrown away
+func DecodeLines[T any](path, pattern string) ([]T, error) {
+	re, holders, err := compilePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("can only decode into a struct, got: %s", t)
+	}
+	fields := make([]int, len(holders))
+	for i, holder := range holders {
+		if holder.name == "_" {
+			continue
+		}
+		if fields[i], err = findField(t, holder); err != nil {
+			return nil, err
+		}
+	}
+
+	lines, err := ReadLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []T
+	for n, line := range lines {
+		if line == "" {
+			continue
+		}
+		match := re.FindStringSubmatch(line)
+		if match == nil {
+			return nil, fmt.Errorf("line %d: %q doesn't match pattern %q", n+1, line, pattern)
+		}
+
+		var value T
+		v := reflect.ValueOf(&value).Elem()
+		for i, holder := range holders {
+			if holder.name == "_" {
+				continue
+			}
+			if err := decodeField(v.Field(fields[i]), holder.kind, match[i+1]); err != nil {
+				return nil, fmt.Errorf("line %d: field %s: %w", n+1, t.Field(fields[i]).Name, err)
+			}
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// compilePattern turns the pattern into a regular expression with a group for
+// each placeholder
+func compilePattern(pattern string) (*regexp.Regexp, []placeholder, error) {
+	var (
+		expr    strings.Builder
+		holders []placeholder
+	)
+	expr.WriteString("^")
+	for rest := pattern; rest != ""; {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			expr.WriteString(regexp.QuoteMeta(rest))
+			break
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return nil, nil, fmt.Errorf("unclosed placeholder in pattern: %q", pattern)
+		}
+		end += start
+
+		name, kind, ok := strings.Cut(rest[start+1:end], ":")
+		if !ok || name == "" {
+			return nil, nil, fmt.Errorf("placeholders are {name:type}, got: %q", rest[start:end+1])
+		}
+		match, ok := placeholders[kind]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown placeholder type: %q", kind)
+		}
+
+		expr.WriteString(regexp.QuoteMeta(rest[:start]))
+		expr.WriteString("(" + match + ")")
+		holders = append(holders, placeholder{name: name, kind: kind})
+		rest = rest[end+1:]
+	}
+	expr.WriteString("$")
+
+	re, err := regexp.Compile(expr.String())
+	return re, holders, err
+}
+
+// findField finds the index of the field for the placeholder, checking it can
+// hold its type
+func findField(t reflect.Type, holder placeholder) (int, error) {
+	index := -1
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if tag, ok := field.Tag.Lookup("decode"); ok {
+			if tag == holder.name {
+				index = i
+				break
+			}
+			continue
+		}
+		if index < 0 && strings.EqualFold(field.Name, holder.name) {
+			index = i
+		}
+	}
+	if index < 0 {
+		return 0, fmt.Errorf("no field for placeholder %q in %s", holder.name, t)
+	}
+
+	field := t.Field(index)
+	if !field.IsExported() {
+		return 0, fmt.Errorf("field %s for placeholder %q isn't exported", field.Name, holder.name)
+	}
+	if !canDecode(holder.kind, field.Type) {
+		return 0, fmt.Errorf("can't decode %s into field %s of type %s", holder.kind, field.Name, field.Type)
+	}
+	return index, nil
+}
+
+func canDecode(kind string, t reflect.Type) bool {
+	switch kind {
+	case "int":
+		return isInt(t)
+	case "word", "string":
+		return t.Kind() == reflect.String
+	case "[]int":
+		return t.Kind() == reflect.Slice && isInt(t.Elem())
+	case "[]word":
+		return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String
+	}
+	return false
+}
+
+func isInt(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func decodeField(v reflect.Value, kind, text string) error {
+	switch kind {
+	case "int":
+		return decodeInt(v, text)
+	case "word", "string":
+		v.SetString(text)
+	case "[]int", "[]word":
+		parts := listSeparator.Split(text, -1)
+		slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if kind == "[]word" {
+				slice.Index(i).SetString(part)
+				continue
+			}
+			if err := decodeInt(slice.Index(i), part); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	}
+	return nil
+}
+
+func decodeInt(v reflect.Value, text string) error {
+	if v.CanUint() {
+		n, err := strconv.ParseUint(strings.TrimPrefix(text, "+"), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+		return nil
+	}
+
+	n, err := strconv.ParseInt(text, 10, v.Type().Bits())
+	if err != nil {
+		return err
+	}
+	v.SetInt(n)
+	return nil
+}
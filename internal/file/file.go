@@ -2,11 +2,14 @@
 package file
 
 import (
-	"bufio"
+	"bytes"
+	"errors"
+	"io"
 	"os"
 
 	"github.com/microhod/adventofcode/internal/encoding/csv"
 	"github.com/microhod/adventofcode/internal/geometry/plane"
+	"github.com/microhod/adventofcode/internal/secret"
 )
 
 func Read(path string) (string, error) {
@@ -14,21 +17,29 @@ func Read(path string) (string, error) {
 	return string(b), err
 }
 
+// ReadBytes reads the file at path, if it doesn't exist but an encrypted copy
+// does e.g. input.txt.enc then that is decrypted instead
 func ReadBytes(path string) ([]byte, error) {
-	return os.ReadFile(path)
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && encrypted(path) {
+		return secret.ReadFile(path)
+	}
+	return b, err
 }
 
-func ReadLines(path string) ([]string, error) {
-	file, err := os.Open(path)
+func ReadLines(path string, opts ...Option) ([]string, error) {
+	file, err := Open(path)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
 
 	lines := []string{}
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
+	for line, err := range Lines(file, opts...) {
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
 	}
 
 	return lines, nil
@@ -125,3 +136,52 @@ func ReadVectorMapFunc[T any](path string, f func(byte) (T, error)) (map[plane.V
 	}
 	return vectormap, nil
 }
+
+// Open opens the file at path, falling back to decrypting the encrypted copy
+// e.g. to use with Lines
+func Open(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) && encrypted(path) {
+		b, err := secret.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func encrypted(path string) bool {
+	_, err := os.Stat(path + secret.Extension)
+	return err == nil
+}
+
+// ReadGrid reads the characters in the file into a grid, every line must be the
+// same length
+func ReadGrid(path string) (plane.Grid[byte], error) {
+	return ReadGridFunc(path, func(b byte) (byte, error) {
+		return b, nil
+	})
+}
+
+func ReadGridFunc[T any](path string, f func(byte) (T, error)) (plane.Grid[T], error) {
+	lines, err := ReadLines(path)
+	if err != nil {
+		return plane.Grid[T]{}, err
+	}
+
+	var rows [][]T
+	for _, line := range lines {
+		row := make([]T, len(line))
+		for x := range line {
+			if row[x], err = f(line[x]); err != nil {
+				return plane.Grid[T]{}, err
+			}
+		}
+		rows = append(rows, row)
+	}
+	return plane.GridFromRows(rows)
+}
@@ -0,0 +1,136 @@
+package file
+
+import (
+	"bufio"
+	"io"
+	"iter"
+)
+
+// DefaultBufferSize is the longest line Lines accepts by default, much longer
+// than bufio's 64KB since some inputs are a single huge line
+const DefaultBufferSize = 16 * 1024 * 1024
+
+type options struct {
+	bufferSize int
+}
+
+type Option func(*options)
+
+// WithBufferSize sets the longest line which can be read by Lines, or the size
+// of the buffer for Runes and Bytes which otherwise use bufio's default
+func WithBufferSize(size int) Option {
+	return func(o *options) {
+		o.bufferSize = size
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// reader buffers r with bufio's default size unless WithBufferSize was used
+func (o options) reader(r io.Reader) *bufio.Reader {
+	if o.bufferSize <= 0 {
+		return bufio.NewReader(r)
+	}
+	return bufio.NewReaderSize(r, o.bufferSize)
+}
+
+// Lines iterates over the lines in r without their line endings, if reading
+// fails the error is yielded last
+func Lines(r io.Reader, opts ...Option) iter.Seq2[string, error] {
+	o := newOptions(opts)
+	size := o.bufferSize
+	if size <= 0 {
+		size = DefaultBufferSize
+	}
+
+	return func(yield func(string, error) bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, min(size, bufio.MaxScanTokenSize)), size)
+
+		for scanner.Scan() {
+			if !yield(scanner.Text(), nil) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield("", err)
+		}
+	}
+}
+
+// Blocks iterates over the groups of lines in r which are separated by blank
+// lines, if reading fails the error is yielded last
+func Blocks(r io.Reader, opts ...Option) iter.Seq2[[]string, error] {
+	return func(yield func([]string, error) bool) {
+		var block []string
+		for line, err := range Lines(r, opts...) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if line != "" {
+				block = append(block, line)
+				continue
+			}
+			if len(block) > 0 && !yield(block, nil) {
+				return
+			}
+			block = nil
+		}
+		if len(block) > 0 {
+			yield(block, nil)
+		}
+	}
+}
+
+// Runes iterates over the utf-8 characters in r, if reading fails the error is
+// yielded last
+func Runes(r io.Reader, opts ...Option) iter.Seq2[rune, error] {
+	o := newOptions(opts)
+
+	return func(yield func(rune, error) bool) {
+		reader := o.reader(r)
+		for {
+			ch, _, err := reader.ReadRune()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(0, err)
+				return
+			}
+			if !yield(ch, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Bytes iterates over the bytes in r, if reading fails the error is yielded
+// last
+func Bytes(r io.Reader, opts ...Option) iter.Seq2[byte, error] {
+	o := newOptions(opts)
+
+	return func(yield func(byte, error) bool) {
+		reader := o.reader(r)
+		for {
+			b, err := reader.ReadByte()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(0, err)
+				return
+			}
+			if !yield(b, nil) {
+				return
+			}
+		}
+	}
+}
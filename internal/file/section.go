@@ -0,0 +1,123 @@
+package file
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Section is a block of lines separated from the others by empty lines, the
+// same as Blocks so lines of only spaces belong to a section
+type Section struct {
+	// Number counts the sections from 1
+	Number int
+	// Header is the first line if it ends with ':' e.g. "seed-to-soil map:" or
+	// "Monkey 0:", otherwise it's empty and the first line is in Lines
+	Header string
+	Lines  []string
+	// start is the line number of Lines[0] in the file
+	start int
+}
+
+// Line is the line number in the file of Lines[i]
+func (s Section) Line(i int) int {
+	return s.start + i
+}
+
+// LineError adds the line number of Lines[i] to err
+func (s Section) LineError(i int, err error) error {
+	return fmt.Errorf("line %d: %w", s.Line(i), err)
+}
+
+// Decoder reads a section, e.g. into a variable captured by the function
+type Decoder func(Section) error
+
+// ReadSections splits the file at path into sections and decodes the nth with
+// the nth decoder, the last decoder is used for any sections after that so
+// e.g. the maps in 2023/05 can share one
+//
+// errors from the decoders are wrapped with the section number and header
+func ReadSections(path string, decoders ...Decoder) ([]Section, error) {
+	file, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var (
+		sections []Section
+		current  *Section
+		number   int
+	)
+	for line, err := range Lines(file) {
+		if err != nil {
+			return nil, err
+		}
+		number++
+
+		if line == "" {
+			current = nil
+			continue
+		}
+		if current == nil {
+			sections = append(sections, Section{Number: len(sections) + 1, start: number})
+			current = &sections[len(sections)-1]
+			if strings.HasSuffix(line, ":") {
+				current.Header = line
+				current.start++
+				continue
+			}
+		}
+		current.Lines = append(current.Lines, line)
+	}
+
+	if len(decoders) == 0 {
+		return sections, nil
+	}
+	for i, section := range sections {
+		decode := decoders[min(i, len(decoders)-1)]
+		if err := decode(section); err != nil {
+			if section.Header != "" {
+				return nil, fmt.Errorf("section %d %q: %w", section.Number, section.Header, err)
+			}
+			return nil, fmt.Errorf("section %d: %w", section.Number, err)
+		}
+	}
+	return sections, nil
+}
+
+// EachLine decodes a section by calling f with each line
+func EachLine(f func(string) error) Decoder {
+	return func(s Section) error {
+		for i, line := range s.Lines {
+			if err := f(line); err != nil {
+				return s.LineError(i, err)
+			}
+		}
+		return nil
+	}
+}
+
+// ParseLines decodes a section by parsing each line and appending it to dst
+func ParseLines[T any](dst *[]T, parse func(string) (T, error)) Decoder {
+	return EachLine(func(line string) error {
+		value, err := parse(line)
+		if err != nil {
+			return err
+		}
+		*dst = append(*dst, value)
+		return nil
+	})
+}
+
+// ParseSection decodes a section by parsing the whole thing and appending it to
+// dst, e.g. a list of monkeys which each have a section
+func ParseSection[T any](dst *[]T, parse func(Section) (T, error)) Decoder {
+	return func(s Section) error {
+		value, err := parse(s)
+		if err != nil {
+			return err
+		}
+		*dst = append(*dst, value)
+		return nil
+	}
+}
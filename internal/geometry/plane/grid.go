@@ -0,0 +1,244 @@
+package plane
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// Grid is a dense rectangle of cells, indexed by vectors from {0, 0} in the top
+// left to {Width-1, Height-1} in the bottom right
+type Grid[T any] struct {
+	Width, Height int
+	cells         []T
+}
+
+func NewGrid[T any](width, height int) Grid[T] {
+	return Grid[T]{Width: width, Height: height, cells: make([]T, width*height)}
+}
+
+// GridFromRows copies the rows into a grid, they must all be the same length
+func GridFromRows[T any](rows [][]T) (Grid[T], error) {
+	if len(rows) == 0 {
+		return Grid[T]{}, nil
+	}
+
+	g := NewGrid[T](len(rows[0]), len(rows))
+	for y, row := range rows {
+		if len(row) != g.Width {
+			return Grid[T]{}, fmt.Errorf("row %d has length %d, expected %d", y, len(row), g.Width)
+		}
+		copy(g.cells[y*g.Width:], row)
+	}
+	return g, nil
+}
+
+// Limit is the bottom right corner, as used by Vector.Within and Draw
+func (g Grid[T]) Limit() Vector {
+	return Vector{X: g.Width - 1, Y: g.Height - 1}
+}
+
+func (g Grid[T]) Within(v Vector) bool {
+	return v.X >= 0 && v.X < g.Width && v.Y >= 0 && v.Y < g.Height
+}
+
+// At panics if v is outside the grid, use Lookup to check
+func (g Grid[T]) At(v Vector) T {
+	if !g.Within(v) {
+		panic(fmt.Sprintf("at: %v is outside the %dx%d grid", v, g.Width, g.Height))
+	}
+	return g.cells[v.Y*g.Width+v.X]
+}
+
+func (g Grid[T]) Lookup(v Vector) (T, bool) {
+	if !g.Within(v) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[v.Y*g.Width+v.X], true
+}
+
+// Set panics if v is outside the grid
+func (g Grid[T]) Set(v Vector, value T) {
+	if !g.Within(v) {
+		panic(fmt.Sprintf("set: %v is outside the %dx%d grid", v, g.Width, g.Height))
+	}
+	g.cells[v.Y*g.Width+v.X] = value
+}
+
+// Neighbours are the neighbours of v in all 8 directions which are inside the
+// grid
+func (g Grid[T]) Neighbours(v Vector) map[Direction]Vector {
+	neighbours := v.Neighbours()
+	for direction, u := range neighbours {
+		if !g.Within(u) {
+			delete(neighbours, direction)
+		}
+	}
+	return neighbours
+}
+
+// OrthogonalNeighbours are the neighbours of v to the north, east, south and
+// west which are inside the grid
+func (g Grid[T]) OrthogonalNeighbours(v Vector) map[Direction]Vector {
+	neighbours := v.OrthogonalNeighbours()
+	for direction, u := range neighbours {
+		if !g.Within(u) {
+			delete(neighbours, direction)
+		}
+	}
+	return neighbours
+}
+
+// All iterates over every cell, row by row
+func (g Grid[T]) All() iter.Seq2[Vector, T] {
+	return func(yield func(Vector, T) bool) {
+		for i, value := range g.cells {
+			if !yield(Vector{X: i % g.Width, Y: i / g.Width}, value) {
+				return
+			}
+		}
+	}
+}
+
+// Row is a copy of row y
+func (g Grid[T]) Row(y int) []T {
+	row := make([]T, g.Width)
+	copy(row, g.cells[y*g.Width:(y+1)*g.Width])
+	return row
+}
+
+// Column is a copy of column x
+func (g Grid[T]) Column(x int) []T {
+	column := make([]T, g.Height)
+	for y := range g.Height {
+		column[y] = g.cells[y*g.Width+x]
+	}
+	return column
+}
+
+func (g Grid[T]) Rows() iter.Seq2[int, []T] {
+	return func(yield func(int, []T) bool) {
+		for y := range g.Height {
+			if !yield(y, g.Row(y)) {
+				return
+			}
+		}
+	}
+}
+
+func (g Grid[T]) Columns() iter.Seq2[int, []T] {
+	return func(yield func(int, []T) bool) {
+		for x := range g.Width {
+			if !yield(x, g.Column(x)) {
+				return
+			}
+		}
+	}
+}
+
+func (g Grid[T]) Clone() Grid[T] {
+	clone := NewGrid[T](g.Width, g.Height)
+	copy(clone.cells, g.cells)
+	return clone
+}
+
+// Transpose swaps the rows and columns, so {x, y} moves to {y, x}
+func (g Grid[T]) Transpose() Grid[T] {
+	return g.transform(g.Height, g.Width, func(v Vector) Vector {
+		return Vector{X: v.Y, Y: v.X}
+	})
+}
+
+// Rotate expects degrees to be a multiple of 90. +ve means clockwise, -ve
+// anti-clockwise
+func (g Grid[T]) Rotate(degrees int) Grid[T] {
+	if degrees%90 != 0 {
+		panic(fmt.Sprintf("rotate: degrees must be multiple of 90, got: %d", degrees))
+	}
+
+	rotated := g.Clone()
+	for range ((degrees/90)%4 + 4) % 4 {
+		// clockwise: the left column becomes the top row
+		height := rotated.Height
+		rotated = rotated.transform(rotated.Height, rotated.Width, func(v Vector) Vector {
+			return Vector{X: height - 1 - v.Y, Y: v.X}
+		})
+	}
+	return rotated
+}
+
+// FlipHorizontal mirrors the grid left to right
+func (g Grid[T]) FlipHorizontal() Grid[T] {
+	return g.transform(g.Width, g.Height, func(v Vector) Vector {
+		return Vector{X: g.Width - 1 - v.X, Y: v.Y}
+	})
+}
+
+// FlipVertical mirrors the grid top to bottom
+func (g Grid[T]) FlipVertical() Grid[T] {
+	return g.transform(g.Width, g.Height, func(v Vector) Vector {
+		return Vector{X: v.X, Y: g.Height - 1 - v.Y}
+	})
+}
+
+// transform moves each cell in g to f(v) in a new width x height grid
+func (g Grid[T]) transform(width, height int, f func(Vector) Vector) Grid[T] {
+	transformed := NewGrid[T](width, height)
+	for v, value := range g.All() {
+		transformed.Set(f(v), value)
+	}
+	return transformed
+}
+
+// Find returns the first cell, row by row, where f is true
+func (g Grid[T]) Find(f func(T) bool) (Vector, bool) {
+	for v, value := range g.All() {
+		if f(value) {
+			return v, true
+		}
+	}
+	return Vector{}, false
+}
+
+// FindAll returns every cell where f is true
+func (g Grid[T]) FindAll(f func(T) bool) []Vector {
+	var vectors []Vector
+	for v, value := range g.All() {
+		if f(value) {
+			vectors = append(vectors, v)
+		}
+	}
+	return vectors
+}
+
+// String draws the grid a row per line, bytes and runes are drawn as
+// characters and everything else with fmt
+func (g Grid[T]) String() string {
+	builder := new(strings.Builder)
+	for y := range g.Height {
+		if y > 0 {
+			builder.WriteByte('\n')
+		}
+		for x := range g.Width {
+			builder.WriteString(cellString(g.cells[y*g.Width+x]))
+		}
+	}
+	return builder.String()
+}
+
+func cellString(value any) string {
+	switch value := value.(type) {
+	case byte:
+		return string(value)
+	case rune:
+		return string(value)
+	case bool:
+		if value {
+			return "#"
+		}
+		return "."
+	default:
+		return fmt.Sprint(value)
+	}
+}
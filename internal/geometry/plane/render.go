@@ -0,0 +1,268 @@
+package plane
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mgutz/ansi"
+)
+
+// Renderer draws cells anywhere on the plane, the bounds are worked out from
+// the cells and overlays so they can be negative
+//
+// all the fields are optional e.g. Renderer[byte]{}.Map(cells)
+type Renderer[T any] struct {
+	// Cell is the text for a value, which can be more than one character, by
+	// default it's the same as Grid.String
+	Cell func(T) string
+	// Style is an ansi style for a cell e.g. "red+b", or "" for none
+	Style func(Vector, T) string
+	// Background is drawn where there isn't a cell, by default "."
+	Background string
+	// Overlays are drawn on top of the cells in order
+	Overlays []Overlay
+	// Axes labels the rows and every fifth column with their coordinates
+	Axes bool
+
+	// Colour is the colour of a cell in a PNG, by default white
+	Colour func(Vector, T) color.Color
+	// Scale is the number of pixels per cell in a PNG, by default 4
+	Scale int
+}
+
+// Overlay highlights some cells e.g. a path or a region
+type Overlay struct {
+	Vectors []Vector
+	// Text replaces the cells if it isn't empty
+	Text string
+	// Style is an ansi style e.g. "yellow+b"
+	Style string
+	// Colour is used in a PNG, nil means the overlay isn't drawn
+	Colour color.Color
+}
+
+// cells is anything which can be drawn, with the box around it
+type cells[T any] struct {
+	min, max Vector
+	lookup   func(Vector) (T, bool)
+	empty    bool
+}
+
+func (r Renderer[T]) Map(m map[Vector]T) string {
+	return r.render(mapCells(m))
+}
+
+func (r Renderer[T]) Grid(g Grid[T]) string {
+	return r.render(gridCells(g))
+}
+
+func (r Renderer[T]) MapPNG(w io.Writer, m map[Vector]T) error {
+	return r.png(w, mapCells(m))
+}
+
+func (r Renderer[T]) GridPNG(w io.Writer, g Grid[T]) error {
+	return r.png(w, gridCells(g))
+}
+
+func mapCells[T any](m map[Vector]T) cells[T] {
+	c := cells[T]{empty: true, lookup: func(v Vector) (T, bool) {
+		value, ok := m[v]
+		return value, ok
+	}}
+	for v := range m {
+		c = c.include(v)
+	}
+	return c
+}
+
+func gridCells[T any](g Grid[T]) cells[T] {
+	return cells[T]{max: g.Limit(), lookup: g.Lookup, empty: g.Width == 0 || g.Height == 0}
+}
+
+// include grows the box to include v
+func (c cells[T]) include(v Vector) cells[T] {
+	if c.empty {
+		c.min, c.max, c.empty = v, v, false
+		return c
+	}
+	c.min = Vector{X: min(c.min.X, v.X), Y: min(c.min.Y, v.Y)}
+	c.max = Vector{X: max(c.max.X, v.X), Y: max(c.max.Y, v.Y)}
+	return c
+}
+
+// withOverlays includes the overlays in the box
+func (r Renderer[T]) withOverlays(c cells[T]) cells[T] {
+	for _, overlay := range r.Overlays {
+		for _, v := range overlay.Vectors {
+			c = c.include(v)
+		}
+	}
+	return c
+}
+
+// overlaid merges the overlays for each cell they cover, later overlays win
+func (r Renderer[T]) overlaid() map[Vector]Overlay {
+	merged := map[Vector]Overlay{}
+	for _, overlay := range r.Overlays {
+		for _, v := range overlay.Vectors {
+			m := merged[v]
+			if overlay.Text != "" {
+				m.Text = overlay.Text
+			}
+			if overlay.Style != "" {
+				m.Style = overlay.Style
+			}
+			if overlay.Colour != nil {
+				m.Colour = overlay.Colour
+			}
+			merged[v] = m
+		}
+	}
+	return merged
+}
+
+// text is the unstyled text for the cell at v and its style
+func (r Renderer[T]) text(c cells[T], overlays map[Vector]Overlay, v Vector) (string, string) {
+	text, style := r.Background, ""
+	if text == "" {
+		text = "."
+	}
+	if value, ok := c.lookup(v); ok {
+		if r.Cell != nil {
+			text = r.Cell(value)
+		} else {
+			text = cellString(value)
+		}
+		if r.Style != nil {
+			style = r.Style(v, value)
+		}
+	}
+
+	if overlay, ok := overlays[v]; ok {
+		if overlay.Text != "" {
+			text = overlay.Text
+		}
+		if overlay.Style != "" {
+			style = overlay.Style
+		}
+	}
+	return text, style
+}
+
+func (r Renderer[T]) render(c cells[T]) string {
+	c = r.withOverlays(c)
+	if c.empty {
+		return ""
+	}
+	overlays := r.overlaid()
+
+	// every cell is padded to the widest one
+	width := 1
+	for y := c.min.Y; y <= c.max.Y; y++ {
+		for x := c.min.X; x <= c.max.X; x++ {
+			text, _ := r.text(c, overlays, Vector{X: x, Y: y})
+			width = max(width, utf8.RuneCountInString(text))
+		}
+	}
+
+	var margin int
+	if r.Axes {
+		margin = max(len(strconv.Itoa(c.min.Y)), len(strconv.Itoa(c.max.Y))) + 1
+	}
+
+	var lines []string
+	if r.Axes {
+		lines = append(lines, columnLabels(c.min.X, c.max.X, width, margin)...)
+	}
+	for y := c.min.Y; y <= c.max.Y; y++ {
+		builder := new(strings.Builder)
+		if r.Axes {
+			label := strconv.Itoa(y)
+			builder.WriteString(strings.Repeat(" ", margin-1-len(label)) + label + " ")
+		}
+		for x := c.min.X; x <= c.max.X; x++ {
+			text, style := r.text(c, overlays, Vector{X: x, Y: y})
+			text += strings.Repeat(" ", width-utf8.RuneCountInString(text))
+			if style != "" {
+				text = ansi.Color(text, style)
+			}
+			builder.WriteString(text)
+		}
+		lines = append(lines, strings.TrimRight(builder.String(), " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// columnLabels writes the x coordinate of every fifth column vertically above
+// it, like the examples in the puzzles
+func columnLabels(minX, maxX, width, margin int) []string {
+	labels := map[int]string{}
+	var height int
+	for x := minX; x <= maxX; x++ {
+		if x%5 == 0 || x == minX || x == maxX {
+			labels[x] = strconv.Itoa(x)
+			height = max(height, len(labels[x]))
+		}
+	}
+
+	var lines []string
+	for row := range height {
+		line := []byte(strings.Repeat(" ", margin+(maxX-minX+1)*width))
+		for x, label := range labels {
+			// right align the labels so the units line up
+			if i := row - (height - len(label)); i >= 0 {
+				line[margin+(x-minX)*width] = label[i]
+			}
+		}
+		lines = append(lines, strings.TrimRight(string(line), " "))
+	}
+	return lines
+}
+
+func (r Renderer[T]) png(w io.Writer, c cells[T]) error {
+	c = r.withOverlays(c)
+	if c.empty {
+		return png.Encode(w, image.NewRGBA(image.Rect(0, 0, 1, 1)))
+	}
+
+	scale := r.Scale
+	if scale <= 0 {
+		scale = 4
+	}
+	size := c.max.Minus(c.min)
+	img := image.NewRGBA(image.Rect(0, 0, (size.X+1)*scale, (size.Y+1)*scale))
+
+	fill := func(v Vector, colour color.Color) {
+		v = v.Minus(c.min)
+		for dy := range scale {
+			for dx := range scale {
+				img.Set(v.X*scale+dx, v.Y*scale+dy, colour)
+			}
+		}
+	}
+
+	for y := c.min.Y; y <= c.max.Y; y++ {
+		for x := c.min.X; x <= c.max.X; x++ {
+			v := Vector{X: x, Y: y}
+			colour := color.Color(color.Black)
+			if value, ok := c.lookup(v); ok {
+				colour = color.White
+				if r.Colour != nil {
+					colour = r.Colour(v, value)
+				}
+			}
+			fill(v, colour)
+		}
+	}
+	for v, overlay := range r.overlaid() {
+		if overlay.Colour != nil {
+			fill(v, overlay.Colour)
+		}
+	}
+	return png.Encode(w, img)
+}
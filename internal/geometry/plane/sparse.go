@@ -0,0 +1,144 @@
+package plane
+
+import "iter"
+
+// SparseGrid is an unbounded grid, only the cells which have been set are
+// stored so it can grow in any direction, every other cell is the background
+type SparseGrid[T comparable] struct {
+	background T
+	// cells are the cells which have been set, even to the background
+	cells map[Vector]T
+	// min and max are the corners of the box around the set cells, they are
+	// recalculated when stale after cells are deleted
+	min, max Vector
+	stale    bool
+}
+
+func NewSparseGrid[T comparable](background T) *SparseGrid[T] {
+	return &SparseGrid[T]{background: background, cells: map[Vector]T{}}
+}
+
+func (g *SparseGrid[T]) Background() T {
+	return g.background
+}
+
+// SetBackground changes the value of every cell which hasn't been set e.g. for
+// an infinite image where the void flips each generation
+func (g *SparseGrid[T]) SetBackground(background T) {
+	g.background = background
+}
+
+// At is the background for cells which haven't been set
+func (g *SparseGrid[T]) At(v Vector) T {
+	if value, ok := g.cells[v]; ok {
+		return value
+	}
+	return g.background
+}
+
+// Set sets the cell even if value is the background, so it keeps its value if
+// the background changes and is inside Bounds
+func (g *SparseGrid[T]) Set(v Vector, value T) {
+	if len(g.cells) == 0 {
+		g.min, g.max, g.stale = v, v, false
+	} else if !g.stale {
+		g.min = Vector{X: min(g.min.X, v.X), Y: min(g.min.Y, v.Y)}
+		g.max = Vector{X: max(g.max.X, v.X), Y: max(g.max.Y, v.Y)}
+	}
+	g.cells[v] = value
+}
+
+// Delete unsets the cell so it's the background again
+func (g *SparseGrid[T]) Delete(v Vector) {
+	if _, ok := g.cells[v]; !ok {
+		return
+	}
+	delete(g.cells, v)
+
+	// the box only needs to shrink if v was on its edge
+	if v.X == g.min.X || v.X == g.max.X || v.Y == g.min.Y || v.Y == g.max.Y {
+		g.stale = true
+	}
+}
+
+// Len is the number of cells which have been set
+func (g *SparseGrid[T]) Len() int {
+	return len(g.cells)
+}
+
+// Count is the number of cells set to value, cells which haven't been set
+// aren't counted since there are infinitely many
+func (g *SparseGrid[T]) Count(value T) int {
+	var count int
+	for _, v := range g.cells {
+		if v == value {
+			count++
+		}
+	}
+	return count
+}
+
+// Bounds are the top left and bottom right corners of the box around every
+// cell which has been set, ok is false if there aren't any
+func (g *SparseGrid[T]) Bounds() (Vector, Vector, bool) {
+	if len(g.cells) == 0 {
+		return Vector{}, Vector{}, false
+	}
+
+	if g.stale {
+		first := true
+		for v := range g.cells {
+			if first {
+				g.min, g.max, first = v, v, false
+				continue
+			}
+			g.min = Vector{X: min(g.min.X, v.X), Y: min(g.min.Y, v.Y)}
+			g.max = Vector{X: max(g.max.X, v.X), Y: max(g.max.Y, v.Y)}
+		}
+		g.stale = false
+	}
+	return g.min, g.max, true
+}
+
+// All iterates over the cells which have been set, in no particular order
+func (g *SparseGrid[T]) All() iter.Seq2[Vector, T] {
+	return func(yield func(Vector, T) bool) {
+		for v, value := range g.cells {
+			if !yield(v, value) {
+				return
+			}
+		}
+	}
+}
+
+func (g *SparseGrid[T]) Clone() *SparseGrid[T] {
+	clone := NewSparseGrid(g.background)
+	for v, value := range g.cells {
+		clone.cells[v] = value
+	}
+	clone.min, clone.max, clone.stale = g.min, g.max, g.stale
+	return clone
+}
+
+// Dense copies the box around the cells into a Grid, along with the top left
+// corner which is {0, 0} in the Grid
+func (g *SparseGrid[T]) Dense() (Grid[T], Vector) {
+	topLeft, bottomRight, ok := g.Bounds()
+	if !ok {
+		return Grid[T]{}, Vector{}
+	}
+
+	size := bottomRight.Minus(topLeft)
+	dense := NewGrid[T](size.X+1, size.Y+1)
+	for v := range dense.All() {
+		dense.Set(v, g.At(v.Add(topLeft)))
+	}
+	return dense, topLeft
+}
+
+// String draws the box around the cells which have been set, the same way as
+// Grid.String, so the top left may be at negative coordinates
+func (g *SparseGrid[T]) String() string {
+	dense, _ := g.Dense()
+	return dense.String()
+}
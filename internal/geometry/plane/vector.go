@@ -61,6 +61,7 @@ func (v Vector) OrthogonalNeighbours() map[Direction]Vector {
 	return neighbours
 }
 
+// Draw draws the parts within [0, limit], Renderer can draw anywhere
 func Draw(parts map[byte][]Vector, limit Vector) string {
 	lines := drawBlank(limit)
 
@@ -0,0 +1,274 @@
+// Package index statically analyses solutions to find out which of the shared
+// internal packages they use, and which of their own types and functions
+// could be shared instead
+package index
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Day is a solution to analyse, Dir is the folder containing its package
+type Day struct {
+	Year, Day int
+	Dir       string
+}
+
+type Report struct {
+	// Packages maps each internal package to the days which import it
+	Packages map[string][]Day
+	// Objects maps each internal function, type, method etc. e.g.
+	// `graph.Graph.Dijkstra` to the days which use it
+	Objects map[string][]Day
+	// Duplicates are types which days define even though there's a shared one
+	Duplicates []Duplicate
+	// Locals maps the types and functions which days define for themselves to
+	// the days which define them, they are candidates to move into internal
+	Locals map[Local][]Day
+	// Errors are the days which couldn't be analysed
+	Errors map[Day]error
+}
+
+type Duplicate struct {
+	Day    Day
+	Local  string
+	Shared string
+	// Identical is true if the types have the same structure, not just the
+	// same name
+	Identical bool
+}
+
+type Local struct {
+	Kind string
+	Name string
+}
+
+type indexer struct {
+	fset     *token.FileSet
+	importer types.Importer
+	internal string
+	// shared are the exported types of the internal packages
+	shared []*types.TypeName
+}
+
+// Analyse type checks each day, module is the module path which the internal
+// folder at root belongs to
+//
+// types in the ignored internal packages e.g. `puzzle` aren't reported as
+// duplicates, since they are for tooling rather than for solutions to share
+func Analyse(module, root string, days []Day, ignore ...string) (*Report, error) {
+	fset := token.NewFileSet()
+	idx := &indexer{
+		fset:     fset,
+		importer: importer.ForCompiler(fset, "source", nil),
+		internal: module + "/internal/",
+	}
+	if err := idx.loadShared(module, root, ignore); err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Packages: map[string][]Day{},
+		Objects:  map[string][]Day{},
+		Locals:   map[Local][]Day{},
+		Errors:   map[Day]error{},
+	}
+	for _, day := range days {
+		if err := idx.analyse(day, report); err != nil {
+			report.Errors[day] = err
+		}
+	}
+	return report, nil
+}
+
+// loadShared type checks every internal package to find the shared types
+func (idx *indexer) loadShared(module, root string, ignore []string) error {
+	return filepath.WalkDir(filepath.Join(root, "internal"), func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return err
+		}
+		if matches, _ := filepath.Glob(filepath.Join(path, "*.go")); len(matches) == 0 {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(ignore, strings.TrimPrefix(filepath.ToSlash(rel), "internal/")) {
+			return nil
+		}
+		pkg, err := idx.importer.Import(module + "/" + filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+
+		scope := pkg.Scope()
+		for _, name := range scope.Names() {
+			if obj, ok := scope.Lookup(name).(*types.TypeName); ok && obj.Exported() {
+				idx.shared = append(idx.shared, obj)
+			}
+		}
+		return nil
+	})
+}
+
+func (idx *indexer) analyse(day Day, report *Report) error {
+	files, err := idx.parse(day.Dir)
+	if err != nil {
+		return err
+	}
+
+	info := &types.Info{Uses: map[*ast.Ident]types.Object{}}
+	config := types.Config{Importer: idx.importer}
+	pkg, err := config.Check(day.Dir, idx.fset, files, info)
+	if err != nil {
+		return err
+	}
+
+	for _, imported := range pkg.Imports() {
+		if strings.HasPrefix(imported.Path(), idx.internal) {
+			path := strings.TrimPrefix(imported.Path(), idx.internal)
+			report.Packages[path] = appendDay(report.Packages[path], day)
+		}
+	}
+
+	for _, obj := range info.Uses {
+		if obj.Pkg() == nil || !strings.HasPrefix(obj.Pkg().Path(), idx.internal) {
+			continue
+		}
+		if name := objectName(obj); name != "" {
+			report.Objects[name] = appendDay(report.Objects[name], day)
+		}
+	}
+
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		switch obj := scope.Lookup(name).(type) {
+		case *types.TypeName:
+			duplicates := idx.duplicates(day, obj)
+			report.Duplicates = append(report.Duplicates, duplicates...)
+			if len(duplicates) == 0 {
+				report.Locals[Local{Kind: "type", Name: name}] = appendDay(report.Locals[Local{Kind: "type", Name: name}], day)
+			}
+		case *types.Func:
+			if !isEntryPoint(name) {
+				report.Locals[Local{Kind: "func", Name: name}] = appendDay(report.Locals[Local{Kind: "func", Name: name}], day)
+			}
+		}
+	}
+	return nil
+}
+
+// parse reads the non test files in the folder
+func (idx *indexer) parse(dir string) ([]*ast.File, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(idx.fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// duplicates finds the shared types with the same name as local, or the same
+// struct fields
+func (idx *indexer) duplicates(day Day, local *types.TypeName) []Duplicate {
+	_, isStruct := local.Type().Underlying().(*types.Struct)
+
+	var duplicates []Duplicate
+	for _, shared := range idx.shared {
+		sameName := strings.EqualFold(local.Name(), shared.Name())
+		identical := types.Identical(local.Type().Underlying(), shared.Type().Underlying())
+		// lots of unrelated types are ints or strings underneath
+		if sameName || (isStruct && identical) {
+			duplicates = append(duplicates, Duplicate{
+				Day:       day,
+				Local:     local.Name(),
+				Shared:    objectName(shared),
+				Identical: identical,
+			})
+		}
+	}
+	return duplicates
+}
+
+// objectName is the name of an object as it would be written outside of its
+// package, methods include their receiver e.g. `plane.Vector.Add`, fields are
+// ignored
+func objectName(obj types.Object) string {
+	prefix := obj.Pkg().Name() + "."
+
+	switch obj := obj.(type) {
+	case *types.Func:
+		obj = obj.Origin()
+		recv := obj.Type().(*types.Signature).Recv()
+		if recv == nil {
+			return prefix + obj.Name()
+		}
+		t := recv.Type()
+		if pointer, ok := t.(*types.Pointer); ok {
+			t = pointer.Elem()
+		}
+		if named, ok := t.(*types.Named); ok {
+			return prefix + named.Obj().Name() + "." + obj.Name()
+		}
+		// methods of interfaces
+		return ""
+	case *types.Var:
+		if obj.IsField() {
+			return ""
+		}
+	case *types.PkgName:
+		return ""
+	}
+	return prefix + obj.Name()
+}
+
+func isEntryPoint(name string) bool {
+	switch name {
+	case "main", "init", "part1", "part2", "parse":
+		return true
+	}
+	return false
+}
+
+func appendDay(days []Day, day Day) []Day {
+	if len(days) > 0 && days[len(days)-1] == day {
+		return days
+	}
+	return append(days, day)
+}
+
+// Sorted returns the keys of counts, most days first
+func Sorted[K comparable](counts map[K][]Day, less func(a, b K) bool) []K {
+	var keys []K
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(counts[keys[i]]) != len(counts[keys[j]]) {
+			return len(counts[keys[i]]) > len(counts[keys[j]])
+		}
+		return less(keys[i], keys[j])
+	})
+	return keys
+}
@@ -0,0 +1,214 @@
+// Package leaderboard computes statistics from a private leaderboard
+package leaderboard
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/microhod/adventofcode/internal/puzzle"
+	"github.com/microhod/adventofcode/internal/set"
+)
+
+// Unlock is the time the puzzle for day is released i.e. midnight EST
+func Unlock(year, day int) time.Time {
+	return time.Date(year, time.December, day, 5, 0, 0, 0, time.UTC)
+}
+
+// Year returns the year of the event the leaderboard is for
+func Year(board *puzzle.Leaderboard) (int, error) {
+	return strconv.Atoi(board.Event)
+}
+
+// Solve is a member's progress on a single day
+type Solve struct {
+	Member       puzzle.Member
+	Day          int
+	Part1, Part2 time.Time
+}
+
+func (s Solve) Complete() bool {
+	return !s.Part2.IsZero()
+}
+
+// Delta is the time between getting the first and second star
+func (s Solve) Delta() (time.Duration, bool) {
+	if !s.Complete() {
+		return 0, false
+	}
+	return s.Part2.Sub(s.Part1), true
+}
+
+// Duration is the time from the puzzle unlocking to getting the last star
+func (s Solve) Duration(year int) time.Duration {
+	last := s.Part1
+	if s.Complete() {
+		last = s.Part2
+	}
+	return last.Sub(Unlock(year, s.Day))
+}
+
+// Solves returns every member's progress on every day, ordered by day then rank
+func Solves(board *puzzle.Leaderboard) []Solve {
+	var solves []Solve
+	for _, member := range board.Ranked() {
+		for day, parts := range member.Completions {
+			solve := Solve{Member: member, Day: day}
+			if star, ok := parts[1]; ok {
+				solve.Part1 = star.Time()
+			}
+			if star, ok := parts[2]; ok {
+				solve.Part2 = star.Time()
+			}
+			solves = append(solves, solve)
+		}
+	}
+
+	rank := ranks(board.Ranked())
+	sort.SliceStable(solves, func(i, j int) bool {
+		if solves[i].Day != solves[j].Day {
+			return solves[i].Day < solves[j].Day
+		}
+		return rank[solves[i].Member.ID] < rank[solves[j].Member.ID]
+	})
+	return solves
+}
+
+// MedianSolveTimes returns the median time to the last star for each member, keyed by member id
+func MedianSolveTimes(board *puzzle.Leaderboard) (map[int]time.Duration, error) {
+	year, err := Year(board)
+	if err != nil {
+		return nil, err
+	}
+
+	durations := map[int][]time.Duration{}
+	for _, solve := range Solves(board) {
+		durations[solve.Member.ID] = append(durations[solve.Member.ID], solve.Duration(year))
+	}
+
+	medians := map[int]time.Duration{}
+	for id, d := range durations {
+		medians[id] = median(d)
+	}
+	return medians, nil
+}
+
+func median(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	mid := len(durations) / 2
+	if len(durations)%2 == 0 {
+		return (durations[mid-1] + durations[mid]) / 2
+	}
+	return durations[mid]
+}
+
+// Scoring is the set of rules used to compute local scores
+type Scoring struct {
+	// Ignore are the days which don't count towards the score e.g. where the
+	// scoring was voided because of an outage
+	Ignore set.Set[int]
+	// Days is the number of days to score, if zero all days are scored
+	Days int
+	// Before only scores the stars earned before this time, if zero all stars
+	// are scored
+	Before time.Time
+}
+
+// Scores recomputes the local score for each member, keyed by member id
+//
+// For each star, the first member to get it scores the number of members,
+// the second one less and so on, which matches the site's local scoring
+func Scores(board *puzzle.Leaderboard, scoring Scoring) (map[int]int, error) {
+	year, err := Year(board)
+	if err != nil {
+		return nil, err
+	}
+	days := scoring.Days
+	if days == 0 {
+		days = puzzle.Days(year)
+	}
+
+	scores := map[int]int{}
+	for _, member := range board.Members {
+		scores[member.ID] = 0
+	}
+
+	for day := 1; day <= days; day++ {
+		if scoring.Ignore.Contains(day) {
+			continue
+		}
+		for _, part := range []int{1, 2} {
+			var stars []puzzle.Member
+			for _, member := range board.Members {
+				star, ok := member.Completions[day][part]
+				if !ok || (!scoring.Before.IsZero() && !star.Time().Before(scoring.Before)) {
+					continue
+				}
+				stars = append(stars, member)
+			}
+			sort.Slice(stars, func(i, j int) bool {
+				return stars[i].Completions[day][part].StarIndex < stars[j].Completions[day][part].StarIndex
+			})
+
+			for i, member := range stars {
+				scores[member.ID] += len(board.Members) - i
+			}
+		}
+	}
+	return scores, nil
+}
+
+// RankHistory returns each member's rank at the end of every day, keyed by member id
+//
+// the end of a day is when the next puzzle unlocks, so only the stars earned
+// by then count
+func RankHistory(board *puzzle.Leaderboard, scoring Scoring) (map[int][]int, error) {
+	year, err := Year(board)
+	if err != nil {
+		return nil, err
+	}
+
+	history := map[int][]int{}
+	for day := 1; day <= puzzle.Days(year); day++ {
+		scoring.Days = day
+		scoring.Before = Unlock(year, day+1)
+		scores, err := Scores(board, scoring)
+		if err != nil {
+			return nil, err
+		}
+
+		for id, rank := range ranks(rankByScore(board, scores)) {
+			history[id] = append(history[id], rank)
+		}
+	}
+	return history, nil
+}
+
+func rankByScore(board *puzzle.Leaderboard, scores map[int]int) []puzzle.Member {
+	members := board.Ranked()
+	sort.SliceStable(members, func(i, j int) bool {
+		return scores[members[i].ID] > scores[members[j].ID]
+	})
+	return members
+}
+
+// ranks maps member id to rank, starting at 1
+func ranks(members []puzzle.Member) map[int]int {
+	rank := map[int]int{}
+	for i, member := range members {
+		rank[member.ID] = i + 1
+	}
+	return rank
+}
+
+func formatDuration(d time.Duration) string {
+	if d >= 24*time.Hour {
+		return fmt.Sprintf(">%dd", int(d.Hours()/24))
+	}
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,125 @@
+package leaderboard
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/microhod/adventofcode/internal/puzzle"
+)
+
+// Table is a grid of results which can be written as text or csv
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+func (t Table) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.Header, "\t"))
+	for _, row := range t.Rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func (t Table) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(t.Rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// DeltaTable lists the time taken to get each star for every member and day
+func DeltaTable(board *puzzle.Leaderboard) (Table, error) {
+	year, err := Year(board)
+	if err != nil {
+		return Table{}, err
+	}
+
+	table := Table{Header: []string{"day", "member", "part 1", "part 2", "delta"}}
+	for _, solve := range Solves(board) {
+		row := []string{fmt.Sprint(solve.Day), solve.Member.DisplayName(), "", "", ""}
+		if !solve.Part1.IsZero() {
+			row[2] = formatDuration(solve.Part1.Sub(Unlock(year, solve.Day)))
+		}
+		if delta, ok := solve.Delta(); ok {
+			row[3] = formatDuration(solve.Part2.Sub(Unlock(year, solve.Day)))
+			row[4] = formatDuration(delta)
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table, nil
+}
+
+// MedianTable lists each member's median solve time
+func MedianTable(board *puzzle.Leaderboard) (Table, error) {
+	medians, err := MedianSolveTimes(board)
+	if err != nil {
+		return Table{}, err
+	}
+
+	table := Table{Header: []string{"member", "stars", "median"}}
+	for _, member := range board.Ranked() {
+		median, ok := medians[member.ID]
+		if !ok {
+			continue
+		}
+		table.Rows = append(table.Rows, []string{
+			member.DisplayName(),
+			fmt.Sprint(member.Stars),
+			formatDuration(median),
+		})
+	}
+	return table, nil
+}
+
+// ScoreTable compares the site's local score with one recomputed using scoring
+func ScoreTable(board *puzzle.Leaderboard, scoring Scoring) (Table, error) {
+	scores, err := Scores(board, scoring)
+	if err != nil {
+		return Table{}, err
+	}
+
+	table := Table{Header: []string{"rank", "member", "local score", "recomputed"}}
+	for i, member := range rankByScore(board, scores) {
+		table.Rows = append(table.Rows, []string{
+			fmt.Sprint(i + 1),
+			member.DisplayName(),
+			fmt.Sprint(member.LocalScore),
+			fmt.Sprint(scores[member.ID]),
+		})
+	}
+	return table, nil
+}
+
+// RankTable lists each member's rank at the end of every day
+func RankTable(board *puzzle.Leaderboard, scoring Scoring) (Table, error) {
+	year, err := Year(board)
+	if err != nil {
+		return Table{}, err
+	}
+	history, err := RankHistory(board, scoring)
+	if err != nil {
+		return Table{}, err
+	}
+
+	table := Table{Header: []string{"member"}}
+	for day := 1; day <= puzzle.Days(year); day++ {
+		table.Header = append(table.Header, fmt.Sprint(day))
+	}
+	for _, member := range board.Ranked() {
+		row := []string{member.DisplayName()}
+		for _, rank := range history[member.ID] {
+			row = append(row, fmt.Sprint(rank))
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table, nil
+}
@@ -3,14 +3,44 @@ package markdown
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 
 	md "github.com/JohannesKaufmann/html-to-markdown"
 	"github.com/PuerkitoBio/goquery"
 )
 
-func AdventOfCode() md.Plugin {
+type options struct {
+	archive string
+}
+
+type Option func(*options)
+
+// WithArchive rewrites links to other puzzles which have a README.md in the
+// archive folder e.g. `2022/14/README.md`, so that they link to each other
+func WithArchive(dir string) Option {
+	return func(o *options) {
+		o.archive = dir
+	}
+}
+
+func AdventOfCode(opts ...Option) md.Plugin {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return func(c *md.Converter) (rules []md.Rule) {
+		// number the easter eggs up front, so the footnotes are in order
+		c.Before(func(selec *goquery.Selection) {
+			selec.Find("span[title]").Each(func(i int, span *goquery.Selection) {
+				span.SetAttr("data-footnote", strconv.Itoa(i+1))
+			})
+		})
+
 		return []md.Rule{
 			{
 				// format title and part numbers correctly
@@ -58,6 +88,56 @@ func AdventOfCode() md.Plugin {
 					return md.String(content)
 				},
 			},
+			{
+				// hover text easter eggs become footnotes
+				Filter: []string{"span"},
+				AdvancedReplacement: func(content string, selec *goquery.Selection, opt *md.Options) (md.AdvancedResult, bool) {
+					title, hasTitle := selec.Attr("title")
+					n, hasFootnote := selec.Attr("data-footnote")
+					if !hasTitle || !hasFootnote {
+						// spans have no rule by default, so keep the content as it is
+						return md.AdvancedResult{Markdown: content}, false
+					}
+
+					return md.AdvancedResult{
+						Markdown: fmt.Sprintf("%s[^%s]", content, n),
+						Footer:   fmt.Sprintf("[^%s]: %s", n, strings.Join(strings.Fields(title), " ")),
+					}, false
+				},
+			},
+			{
+				// link to other puzzles in the archive
+				Filter: []string{"a"},
+				Replacement: func(content string, selec *goquery.Selection, opt *md.Options) *string {
+					if o.archive == "" {
+						return nil
+					}
+					path, ok := ArchivePath(o.archive, selec.AttrOr("href", ""))
+					if !ok {
+						return nil
+					}
+					return md.String(fmt.Sprintf("[%s](%s)", content, path))
+				},
+			},
 		}
 	}
 }
+
+var puzzleURL = regexp.MustCompile(`^https?://adventofcode\.com/(\d{4})/day/(\d{1,2})(?:#.*)?$`)
+
+// ArchivePath is the relative path from one README.md in the archive to the
+// README.md for the puzzle at url, if it exists
+func ArchivePath(archive, url string) (string, bool) {
+	match := puzzleURL.FindStringSubmatch(url)
+	if match == nil {
+		return "", false
+	}
+	year, _ := strconv.Atoi(match[1])
+	day, _ := strconv.Atoi(match[2])
+
+	path := fmt.Sprintf("%d/%02d/README.md", year, day)
+	if _, err := os.Stat(filepath.Join(archive, path)); err != nil {
+		return "", false
+	}
+	return "../../" + path, true
+}
@@ -0,0 +1,311 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mgutz/ansi"
+)
+
+var (
+	headingStyle  = ansi.ColorFunc("green+bh")
+	boldStyle     = ansi.ColorFunc("white+bh")
+	emphasisStyle = ansi.ColorFunc("yellow+bh")
+	codeStyle     = ansi.ColorFunc("cyan")
+	linkStyle     = ansi.ColorFunc("blue+u")
+	boxStyle      = ansi.ColorFunc("black+h")
+)
+
+// Terminal renders the markdown of a puzzle description with ANSI styles,
+// wrapping paragraphs to width
+//
+// emphasis from the puzzle e.g. `_text_` and the `<b>` tags in code blocks are
+// highlighted, and links are listed as footnotes at the end
+func Terminal(markdown string, width int) string {
+	r := &renderer{width: width}
+
+	lines := strings.Split(markdown, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			continue
+		case trimmed == "<pre>" || strings.HasPrefix(trimmed, "```"):
+			end := "</pre>"
+			if trimmed != "<pre>" {
+				end = "```"
+			}
+			var block []string
+			for i++; i < len(lines) && strings.TrimSpace(lines[i]) != end; i++ {
+				block = append(block, lines[i])
+			}
+			r.codeBlock(block)
+		case strings.HasPrefix(trimmed, "#"):
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			r.heading(level, strings.TrimSpace(trimmed[level:]))
+		case strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* "):
+			r.paragraph(trimmed[2:], "  • ", "    ")
+		default:
+			// a paragraph continues until a blank line
+			paragraph := []string{trimmed}
+			for i+1 < len(lines) && isContinuation(lines[i+1]) {
+				i++
+				paragraph = append(paragraph, strings.TrimSpace(lines[i]))
+			}
+			r.paragraph(strings.Join(paragraph, " "), "", "")
+		}
+	}
+
+	r.footnotes()
+	return strings.TrimRight(r.out.String(), "\n") + "\n"
+}
+
+func isContinuation(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	for _, prefix := range []string{"#", "- ", "* ", "<pre>", "```"} {
+		if strings.HasPrefix(trimmed, prefix) {
+			return false
+		}
+	}
+	return trimmed != ""
+}
+
+type renderer struct {
+	width int
+	out   strings.Builder
+	links []string
+}
+
+func (r *renderer) heading(level int, text string) {
+	if level == 1 {
+		text = strings.ToUpper(text)
+	}
+	fmt.Fprintf(&r.out, "%s\n\n", headingStyle(text))
+}
+
+// codeBlock draws a box around the lines, which aren't wrapped
+func (r *renderer) codeBlock(lines []string) {
+	// skip the blank line which often starts a <pre> block
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+
+	var width int
+	for _, line := range lines {
+		width = max(width, utf8.RuneCountInString(stripBold(line)))
+	}
+
+	fmt.Fprintln(&r.out, boxStyle("┌"+strings.Repeat("─", width+2)+"┐"))
+	for _, line := range lines {
+		padding := strings.Repeat(" ", width-utf8.RuneCountInString(stripBold(line)))
+		fmt.Fprintf(&r.out, "%s %s%s %s\n", boxStyle("│"), highlight(line), padding, boxStyle("│"))
+	}
+	fmt.Fprintf(&r.out, "%s\n\n", boxStyle("└"+strings.Repeat("─", width+2)+"┘"))
+}
+
+func stripBold(line string) string {
+	return strings.NewReplacer("<b>", "", "</b>", "").Replace(line)
+}
+
+// highlight highlights the <b> sections of a code block line, which were <em>
+// in the puzzle
+func highlight(line string) string {
+	builder := new(strings.Builder)
+	for {
+		before, after, found := strings.Cut(line, "<b>")
+		builder.WriteString(before)
+		if !found {
+			return builder.String()
+		}
+		bold, rest, _ := strings.Cut(after, "</b>")
+		builder.WriteString(emphasisStyle(bold))
+		line = rest
+	}
+}
+
+// paragraph wraps the text, the first line starts with prefix and the others
+// with indent
+func (r *renderer) paragraph(text, prefix, indent string) {
+	lineWidth := utf8.RuneCountInString(prefix)
+	r.out.WriteString(prefix)
+
+	for i, word := range r.words(text) {
+		length := word.length()
+		if i > 0 && lineWidth+1+length > r.width {
+			r.out.WriteString("\n" + indent)
+			lineWidth = utf8.RuneCountInString(indent)
+		} else if i > 0 {
+			r.out.WriteString(" ")
+			lineWidth++
+		}
+		r.out.WriteString(word.String())
+		lineWidth += length
+	}
+	r.out.WriteString("\n\n")
+}
+
+func (r *renderer) footnotes() {
+	if len(r.links) == 0 {
+		return
+	}
+	fmt.Fprintln(&r.out, boxStyle(strings.Repeat("─", min(r.width, 20))))
+	for i, link := range r.links {
+		fmt.Fprintf(&r.out, "[%d] %s\n", i+1, link)
+	}
+}
+
+type style int
+
+const (
+	plainText style = iota
+	boldText
+	emphasisText
+	codeText
+	linkText
+)
+
+var styles = map[style]func(string) string{
+	boldText:     boldStyle,
+	emphasisText: emphasisStyle,
+	codeText:     codeStyle,
+	linkText:     linkStyle,
+}
+
+type segment struct {
+	text  string
+	style style
+}
+
+// word is the segments between two spaces, each of which can be styled
+// differently e.g. `_bold_,`
+type word []segment
+
+func (w word) length() int {
+	var length int
+	for _, s := range w {
+		length += utf8.RuneCountInString(s.text)
+	}
+	return length
+}
+
+func (w word) String() string {
+	builder := new(strings.Builder)
+	for _, s := range w {
+		if s.style == plainText {
+			builder.WriteString(s.text)
+		} else {
+			builder.WriteString(styles[s.style](s.text))
+		}
+	}
+	return builder.String()
+}
+
+// words parses the inline markdown in text into styled words
+func (r *renderer) words(text string) []word {
+	var words []word
+	w := word{}
+	var isBold, isEmphasis bool
+
+	current := func() style {
+		switch {
+		case isBold:
+			return boldText
+		case isEmphasis:
+			return emphasisText
+		}
+		return plainText
+	}
+	// add writes text to the current word, splitting it into new words on
+	// spaces
+	add := func(text string, style style) {
+		for i, part := range strings.Split(text, " ") {
+			if i > 0 {
+				if len(w) > 0 {
+					words = append(words, w)
+				}
+				w = word{}
+			}
+			if part == "" {
+				continue
+			}
+			// merge with the previous segment if it has the same style
+			if n := len(w); n > 0 && w[n-1].style == style {
+				w[n-1].text += part
+			} else {
+				w = append(w, segment{text: part, style: style})
+			}
+		}
+	}
+
+	runes := []rune(text)
+	isWord := func(i int) bool {
+		return i >= 0 && i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]))
+	}
+	for i := 0; i < len(runes); i++ {
+		rest := string(runes[i:])
+
+		switch {
+		case runes[i] == '\\' && i+1 < len(runes) && unicode.IsPunct(runes[i+1]):
+			i++
+			add(string(runes[i]), current())
+		case runes[i] == '`':
+			code, _, found := strings.Cut(string(runes[i+1:]), "`")
+			if !found {
+				add("`", current())
+				continue
+			}
+			add(code, codeText)
+			i += utf8.RuneCountInString(code) + 1
+		case strings.HasPrefix(rest, "**"):
+			isBold = !isBold
+			i++
+		case runes[i] == '_' && !isEmphasis && !isWord(i-1) && i+1 < len(runes) && runes[i+1] != ' ':
+			isEmphasis = true
+		case runes[i] == '_' && isEmphasis && !isWord(i+1):
+			isEmphasis = false
+		case runes[i] == '[':
+			label, url, n, ok := parseLink(runes[i:])
+			if !ok {
+				add("[", current())
+				continue
+			}
+			r.links = append(r.links, url)
+			add(label, linkText)
+			add(fmt.Sprintf("[%d]", len(r.links)), plainText)
+			i += n - 1
+		case runes[i] == '<' && (strings.HasPrefix(rest, "<http://") || strings.HasPrefix(rest, "<https://")):
+			url, _, found := strings.Cut(rest[1:], ">")
+			if !found {
+				add("<", current())
+				continue
+			}
+			add(url, linkText)
+			i += utf8.RuneCountInString(url) + 1
+		default:
+			add(string(runes[i]), current())
+		}
+	}
+	if len(w) > 0 {
+		words = append(words, w)
+	}
+	return words
+}
+
+// parseLink parses `[label](url)` from the start of runes, returning the
+// number of runes it took up
+func parseLink(runes []rune) (string, string, int, bool) {
+	text := string(runes)
+	label, rest, found := strings.Cut(text[1:], "](")
+	if !found || strings.Contains(label, "]") {
+		return "", "", 0, false
+	}
+	url, _, found := strings.Cut(rest, ")")
+	if !found || strings.Contains(url, " ") {
+		return "", "", 0, false
+	}
+	return label, url, utf8.RuneCountInString(label) + utf8.RuneCountInString(url) + 4, true
+}
@@ -0,0 +1,82 @@
+package maths
+
+import "math/big"
+
+// BigNumber is an arbitrary precision number, the helpers for them always
+// return a new value rather than changing their arguments
+type BigNumber interface {
+	*big.Int | *big.Rat
+}
+
+func BigSum[T BigNumber](nums ...T) T {
+	switch nums := any(nums).(type) {
+	case []*big.Int:
+		sum := new(big.Int)
+		for _, n := range nums {
+			sum.Add(sum, n)
+		}
+		return any(sum).(T)
+	case []*big.Rat:
+		sum := new(big.Rat)
+		for _, n := range nums {
+			sum.Add(sum, n)
+		}
+		return any(sum).(T)
+	}
+	panic("unreachable")
+}
+
+// BigGcd is the greatest common divisor, for fractions it's the largest
+// fraction which divides them all a whole number of times
+func BigGcd[T BigNumber](nums ...T) T {
+	return bigFold(nums, bigGcd)
+}
+
+// BigLcm is the lowest common multiple, for fractions it's the smallest
+// fraction which they all divide a whole number of times
+func BigLcm[T BigNumber](nums ...T) T {
+	return bigFold(nums, bigLcm)
+}
+
+// bigFold combines the numbers pairwise with f, fractions p/q and r/s are
+// combined as f(p*s, r*q) / q*s
+func bigFold[T BigNumber](nums []T, f func(a, b *big.Int) *big.Int) T {
+	switch nums := any(nums).(type) {
+	case []*big.Int:
+		if len(nums) == 0 {
+			return any(new(big.Int)).(T)
+		}
+		result := new(big.Int).Set(nums[0])
+		for _, n := range nums[1:] {
+			result = f(result, n)
+		}
+		return any(result).(T)
+	case []*big.Rat:
+		if len(nums) == 0 {
+			return any(new(big.Rat)).(T)
+		}
+		result := new(big.Rat).Set(nums[0])
+		for _, n := range nums[1:] {
+			a := new(big.Int).Mul(result.Num(), n.Denom())
+			b := new(big.Int).Mul(n.Num(), result.Denom())
+			denom := new(big.Int).Mul(result.Denom(), n.Denom())
+			result = new(big.Rat).SetFrac(f(a, b), denom)
+		}
+		return any(result).(T)
+	}
+	panic("unreachable")
+}
+
+func bigGcd(a, b *big.Int) *big.Int {
+	return new(big.Int).GCD(nil, nil, a, b)
+}
+
+func bigLcm(a, b *big.Int) *big.Int {
+	gcd := bigGcd(a, b)
+	if gcd.Sign() == 0 {
+		return gcd
+	}
+	lcm := new(big.Int).Mul(a, b)
+	lcm.Abs(lcm)
+	return lcm.Quo(lcm, gcd)
+}
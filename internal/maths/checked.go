@@ -0,0 +1,79 @@
+package maths
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var ErrOverflow = errors.New("integer overflow")
+
+// AddInt64 is a + b, or ErrOverflow if it doesn't fit in an int64
+func AddInt64(a, b int64) (int64, error) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, fmt.Errorf("%w: %d + %d", ErrOverflow, a, b)
+	}
+	return a + b, nil
+}
+
+// SubInt64 is a - b, or ErrOverflow if it doesn't fit in an int64
+func SubInt64(a, b int64) (int64, error) {
+	if (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b) {
+		return 0, fmt.Errorf("%w: %d - %d", ErrOverflow, a, b)
+	}
+	return a - b, nil
+}
+
+// MulInt64 is a * b, or ErrOverflow if it doesn't fit in an int64
+func MulInt64(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, fmt.Errorf("%w: %d * %d", ErrOverflow, a, b)
+	}
+	return product, nil
+}
+
+// SumInt64 adds up the numbers, or ErrOverflow if any partial sum doesn't fit
+// in an int64
+func SumInt64(nums ...int64) (int64, error) {
+	var sum int64
+	for _, n := range nums {
+		var err error
+		if sum, err = AddInt64(sum, n); err != nil {
+			return 0, err
+		}
+	}
+	return sum, nil
+}
+
+// MustAddInt64 is AddInt64 which panics on overflow, for when it would be a
+// bug rather than bad input
+func MustAddInt64(a, b int64) int64 {
+	return must(AddInt64(a, b))
+}
+
+// MustSubInt64 is SubInt64 which panics on overflow
+func MustSubInt64(a, b int64) int64 {
+	return must(SubInt64(a, b))
+}
+
+// MustMulInt64 is MulInt64 which panics on overflow
+func MustMulInt64(a, b int64) int64 {
+	return must(MulInt64(a, b))
+}
+
+// MustSumInt64 is SumInt64 which panics on overflow
+func MustSumInt64(nums ...int64) int64 {
+	return must(SumInt64(nums...))
+}
+
+// must panics if there was an error
+func must(n int64, err error) int64 {
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
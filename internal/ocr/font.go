@@ -0,0 +1,324 @@
+package ocr
+
+import "strings"
+
+// small and large map each glyph, as drawn by glyphs, to its letter
+var (
+	small = font(smallLetters)
+	large = font(largeLetters)
+)
+
+// font trims the blank columns around the letters, so they match the glyphs
+// split out of a drawing
+func font(letters map[rune]string) map[string]rune {
+	glyphLetters := map[string]rune{}
+	for letter, drawing := range letters {
+		var pixels [][]bool
+		for _, line := range strings.Split(strings.TrimSpace(drawing), "\n") {
+			var row []bool
+			for _, ch := range strings.TrimSpace(line) {
+				row = append(row, ch == '#')
+			}
+			pixels = append(pixels, row)
+		}
+		glyphLetters[glyphs(pixels)[0]] = letter
+	}
+	return glyphLetters
+}
+
+var smallLetters = map[rune]string{
+	'A': `
+		.##.
+		#..#
+		#..#
+		####
+		#..#
+		#..#`,
+	'B': `
+		###.
+		#..#
+		###.
+		#..#
+		#..#
+		###.`,
+	'C': `
+		.##.
+		#..#
+		#...
+		#...
+		#..#
+		.##.`,
+	'E': `
+		####
+		#...
+		###.
+		#...
+		#...
+		####`,
+	'F': `
+		####
+		#...
+		###.
+		#...
+		#...
+		#...`,
+	'G': `
+		.##.
+		#..#
+		#...
+		#.##
+		#..#
+		.###`,
+	'H': `
+		#..#
+		#..#
+		####
+		#..#
+		#..#
+		#..#`,
+	'I': `
+		.###
+		..#.
+		..#.
+		..#.
+		..#.
+		.###`,
+	'J': `
+		..##
+		...#
+		...#
+		...#
+		#..#
+		.##.`,
+	'K': `
+		#..#
+		#.#.
+		##..
+		#.#.
+		#.#.
+		#..#`,
+	'L': `
+		#...
+		#...
+		#...
+		#...
+		#...
+		####`,
+	'O': `
+		.##.
+		#..#
+		#..#
+		#..#
+		#..#
+		.##.`,
+	'P': `
+		###.
+		#..#
+		#..#
+		###.
+		#...
+		#...`,
+	'R': `
+		###.
+		#..#
+		#..#
+		###.
+		#.#.
+		#..#`,
+	'S': `
+		.###
+		#...
+		#...
+		.##.
+		...#
+		###.`,
+	'U': `
+		#..#
+		#..#
+		#..#
+		#..#
+		#..#
+		.##.`,
+	'Y': `
+		#...#
+		#...#
+		.#.#.
+		..#..
+		..#..
+		..#..`,
+	'Z': `
+		####
+		...#
+		..#.
+		.#..
+		#...
+		####`,
+}
+
+var largeLetters = map[rune]string{
+	'A': `
+		..##..
+		.#..#.
+		#....#
+		#....#
+		#....#
+		######
+		#....#
+		#....#
+		#....#
+		#....#`,
+	'B': `
+		#####.
+		#....#
+		#....#
+		#....#
+		#####.
+		#....#
+		#....#
+		#....#
+		#....#
+		#####.`,
+	'C': `
+		.####.
+		#....#
+		#.....
+		#.....
+		#.....
+		#.....
+		#.....
+		#.....
+		#....#
+		.####.`,
+	'E': `
+		######
+		#.....
+		#.....
+		#.....
+		#####.
+		#.....
+		#.....
+		#.....
+		#.....
+		######`,
+	'F': `
+		######
+		#.....
+		#.....
+		#.....
+		#####.
+		#.....
+		#.....
+		#.....
+		#.....
+		#.....`,
+	'G': `
+		.####.
+		#....#
+		#.....
+		#.....
+		#.....
+		#..###
+		#....#
+		#....#
+		#...##
+		.###.#`,
+	'H': `
+		#....#
+		#....#
+		#....#
+		#....#
+		######
+		#....#
+		#....#
+		#....#
+		#....#
+		#....#`,
+	'J': `
+		...###
+		....#.
+		....#.
+		....#.
+		....#.
+		....#.
+		....#.
+		#...#.
+		#...#.
+		.###..`,
+	'K': `
+		#....#
+		#...#.
+		#..#..
+		#.#...
+		##....
+		##....
+		#.#...
+		#..#..
+		#...#.
+		#....#`,
+	'L': `
+		#.....
+		#.....
+		#.....
+		#.....
+		#.....
+		#.....
+		#.....
+		#.....
+		#.....
+		######`,
+	'N': `
+		#....#
+		##...#
+		##...#
+		#.#..#
+		#.#..#
+		#..#.#
+		#..#.#
+		#...##
+		#...##
+		#....#`,
+	'P': `
+		#####.
+		#....#
+		#....#
+		#....#
+		#####.
+		#.....
+		#.....
+		#.....
+		#.....
+		#.....`,
+	'R': `
+		#####.
+		#....#
+		#....#
+		#....#
+		#####.
+		#..#..
+		#...#.
+		#...#.
+		#....#
+		#....#`,
+	'X': `
+		#....#
+		#....#
+		.#..#.
+		.#..#.
+		..##..
+		..##..
+		.#..#.
+		.#..#.
+		#....#
+		#....#`,
+	'Z': `
+		######
+		.....#
+		.....#
+		....#.
+		...#..
+		..#...
+		.#....
+		#.....
+		#.....
+		######`,
+}
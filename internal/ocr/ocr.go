@@ -0,0 +1,154 @@
+// Package ocr reads the block letters which some puzzles draw as their answer
+// e.g. the CRT in 2022/10 or the folded paper in 2021/13
+//
+// the letters are in one of two fonts, 4x6 or 6x10 which is recognised from the
+// height of the drawing
+package ocr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/microhod/adventofcode/internal/geometry/plane"
+	"github.com/microhod/adventofcode/internal/set"
+)
+
+var ErrUnknownGlyph = errors.New("unknown glyph")
+
+// Read reads the letters in pixels, where pixels[y][x] is true if the pixel is
+// lit
+func Read(pixels [][]bool) (string, error) {
+	pixels = trimRows(pixels)
+	if len(pixels) == 0 {
+		return "", nil
+	}
+
+	var font map[string]rune
+	switch len(pixels) {
+	case 6:
+		font = small
+	case 10:
+		font = large
+	default:
+		return "", fmt.Errorf("letters are 6 or 10 pixels high, got: %d", len(pixels))
+	}
+
+	var letters []rune
+	for i, glyph := range glyphs(pixels) {
+		letter, ok := font[glyph]
+		if !ok {
+			return "", fmt.Errorf("%w at letter %d:\n%s", ErrUnknownGlyph, i+1, glyph)
+		}
+		letters = append(letters, letter)
+	}
+	return string(letters), nil
+}
+
+// ReadString reads the letters from a drawing like the output of plane.Draw,
+// where '#' and '█' are lit and everything else isn't
+func ReadString(drawing string) (string, error) {
+	var pixels [][]bool
+	for _, line := range strings.Split(strings.TrimRight(drawing, "\n"), "\n") {
+		var row []bool
+		for _, ch := range line {
+			row = append(row, ch == '#' || ch == '█')
+		}
+		pixels = append(pixels, row)
+	}
+	return Read(pixels)
+}
+
+// ReadVectors reads the letters drawn by the lit vectors, which can be
+// anywhere on the plane
+func ReadVectors(lit set.Set[plane.Vector]) (string, error) {
+	if len(lit) == 0 {
+		return "", nil
+	}
+
+	topLeft, bottomRight := plane.Vector{}, plane.Vector{}
+	first := true
+	for v := range lit {
+		if first {
+			topLeft, bottomRight, first = v, v, false
+			continue
+		}
+		topLeft = plane.Vector{X: min(topLeft.X, v.X), Y: min(topLeft.Y, v.Y)}
+		bottomRight = plane.Vector{X: max(bottomRight.X, v.X), Y: max(bottomRight.Y, v.Y)}
+	}
+
+	size := bottomRight.Minus(topLeft)
+	pixels := make([][]bool, size.Y+1)
+	for y := range pixels {
+		pixels[y] = make([]bool, size.X+1)
+	}
+	for v := range lit {
+		pixels[v.Y-topLeft.Y][v.X-topLeft.X] = true
+	}
+	return Read(pixels)
+}
+
+// trimRows removes the blank rows above and below the letters
+func trimRows(pixels [][]bool) [][]bool {
+	blank := func(row []bool) bool {
+		for _, lit := range row {
+			if lit {
+				return false
+			}
+		}
+		return true
+	}
+
+	for len(pixels) > 0 && blank(pixels[0]) {
+		pixels = pixels[1:]
+	}
+	for len(pixels) > 0 && blank(pixels[len(pixels)-1]) {
+		pixels = pixels[:len(pixels)-1]
+	}
+	return pixels
+}
+
+// glyphs splits the pixels into letters at the blank columns between them, each
+// letter is drawn with '#' and '.' a row per line
+func glyphs(pixels [][]bool) []string {
+	var width int
+	for _, row := range pixels {
+		width = max(width, len(row))
+	}
+	lit := func(x, y int) bool {
+		return x < len(pixels[y]) && pixels[y][x]
+	}
+	blank := func(x int) bool {
+		for y := range pixels {
+			if lit(x, y) {
+				return false
+			}
+		}
+		return true
+	}
+
+	var glyphs []string
+	for x := 0; x < width; x++ {
+		if blank(x) {
+			continue
+		}
+		start := x
+		for x < width && !blank(x) {
+			x++
+		}
+
+		var rows []string
+		for y := range pixels {
+			row := make([]byte, x-start)
+			for i := range row {
+				row[i] = '.'
+				if lit(start+i, y) {
+					row[i] = '#'
+				}
+			}
+			rows = append(rows, string(row))
+		}
+		glyphs = append(glyphs, strings.Join(rows, "\n"))
+	}
+	return glyphs
+}
@@ -0,0 +1,61 @@
+package puzzle
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// cache stores responses on disk so that we don't hit adventofcode.com more
+// often than needed e.g. leaderboards should only be polled every 15 minutes
+// https://www.reddit.com/r/adventofcode/wiki/faqs/automation
+type cache struct {
+	dir string
+}
+
+func newCache(dir string) *cache {
+	return &cache{dir: dir}
+}
+
+// DefaultCacheDir is where aoc caches responses and anything else it can rebuild
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "adventofcode")
+}
+
+// get returns the cached value for path if it is younger than ttl
+func (c *cache) get(path string, ttl time.Duration) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	info, err := os.Stat(c.file(path))
+	if err != nil || time.Since(info.ModTime()) > ttl {
+		return nil, false
+	}
+
+	bytes, err := os.ReadFile(c.file(path))
+	if err != nil {
+		return nil, false
+	}
+	return bytes, true
+}
+
+func (c *cache) put(path string, bytes []byte) error {
+	if c == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(c.dir, os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(c.file(path), bytes, 0600)
+}
+
+func (c *cache) file(path string) string {
+	return filepath.Join(c.dir, strings.ReplaceAll(strings.Trim(path, "/"), "/", "_"))
+}
@@ -0,0 +1,197 @@
+package puzzle
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+const (
+	// colours used on the site for the star marks
+	SilverStar = "#9999cc"
+	GoldStar   = "#ffff66"
+)
+
+// Calendar is the ascii art on the page for a year, which fills in as stars
+// are earned
+type Calendar struct {
+	Year int
+	// Stars maps day -> number of stars earned (0, 1 or 2)
+	Stars map[int]int
+	Lines [][]Span
+}
+
+// Span is a run of art in a single colour
+type Span struct {
+	Text string
+	// Colour is a css colour e.g. #ffff66, empty means the default colour
+	Colour string
+	// Day the art belongs to, 0 if it isn't part of a day
+	Day int
+}
+
+func (c *Calendar) TotalStars() int {
+	var total int
+	for _, s := range c.Stars {
+		total += s
+	}
+	return total
+}
+
+// Calendar gets the calendar for year, responses are cached for LeaderboardTTL
+func (client *Client) Calendar(year int) (*Calendar, error) {
+	path := fmt.Sprintf("%d", year)
+
+	body, err := client.getCached(path, LeaderboardTTL, loggedInHTML)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	return parseCalendar(year, doc)
+}
+
+var (
+	dayClassRegex   = regexp.MustCompile(`calendar-day(\d+)`)
+	colourRuleRegex = regexp.MustCompile(`\.(calendar-[\w-]+)\s*\{[^}]*?color:\s*(#[0-9a-fA-F]{3,6})`)
+	inlineColour    = regexp.MustCompile(`(?:^|;)\s*color:\s*(#[0-9a-fA-F]{3,6})`)
+)
+
+func parseCalendar(year int, doc *goquery.Document) (*Calendar, error) {
+	pre := doc.Find("pre.calendar").First()
+	if pre.Length() == 0 {
+		return nil, fmt.Errorf("no calendar found for %d", year)
+	}
+
+	// some years define the art colours in a style tag rather than inline
+	colours := map[string]string{}
+	doc.Find("style").Each(func(_ int, style *goquery.Selection) {
+		for _, match := range colourRuleRegex.FindAllStringSubmatch(style.Text(), -1) {
+			colours[match[1]] = match[2]
+		}
+	})
+	colours["calendar-mark-complete"] = SilverStar
+	colours["calendar-mark-verycomplete"] = GoldStar
+
+	calendar := &Calendar{Year: year, Stars: map[int]int{}}
+	pre.Find("a[class*=calendar-day]").Each(func(_ int, a *goquery.Selection) {
+		day := classDay(a)
+		switch {
+		case a.HasClass("calendar-verycomplete"):
+			calendar.Stars[day] = 2
+		case a.HasClass("calendar-complete"):
+			calendar.Stars[day] = 1
+		default:
+			calendar.Stars[day] = 0
+		}
+	})
+
+	parser := &calendarParser{calendar: calendar, colours: colours, line: []Span{}}
+	for _, node := range pre.Nodes {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			parser.walk(child, "", 0)
+		}
+	}
+	parser.endLine()
+
+	// the art often starts and ends with a blank line
+	for len(calendar.Lines) > 0 && len(calendar.Lines[0]) == 0 {
+		calendar.Lines = calendar.Lines[1:]
+	}
+	for n := len(calendar.Lines); n > 0 && len(calendar.Lines[n-1]) == 0; n-- {
+		calendar.Lines = calendar.Lines[:n-1]
+	}
+	return calendar, nil
+}
+
+type calendarParser struct {
+	calendar *Calendar
+	colours  map[string]string
+	line     []Span
+}
+
+func (p *calendarParser) walk(node *html.Node, colour string, day int) {
+	if node.Type == html.TextNode {
+		p.text(node.Data, colour, day)
+		return
+	}
+	if node.Type != html.ElementNode {
+		return
+	}
+
+	selection := goquery.NewDocumentFromNode(node).Selection
+	if d := classDay(selection); d > 0 {
+		day = d
+	}
+	if c := p.colour(selection); c != "" {
+		colour = c
+	}
+
+	// star marks are always in the html, but only shown once earned
+	if selection.HasClass("calendar-mark-complete") && p.calendar.Stars[day] < 1 ||
+		selection.HasClass("calendar-mark-verycomplete") && p.calendar.Stars[day] < 2 {
+		p.text(strings.Repeat(" ", len([]rune(selection.Text()))), "", day)
+		return
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		p.walk(child, colour, day)
+	}
+}
+
+func (p *calendarParser) text(text, colour string, day int) {
+	for i, part := range strings.Split(text, "\n") {
+		if i > 0 {
+			p.endLine()
+		}
+		if part == "" {
+			continue
+		}
+
+		// merge with the previous span if it looks the same
+		if n := len(p.line); n > 0 && p.line[n-1].Colour == colour && p.line[n-1].Day == day {
+			p.line[n-1].Text += part
+			continue
+		}
+		p.line = append(p.line, Span{Text: part, Colour: colour, Day: day})
+	}
+}
+
+func (p *calendarParser) endLine() {
+	p.calendar.Lines = append(p.calendar.Lines, p.line)
+	p.line = []Span{}
+}
+
+func (p *calendarParser) colour(selection *goquery.Selection) string {
+	if style, ok := selection.Attr("style"); ok {
+		if match := inlineColour.FindStringSubmatch(style); match != nil {
+			return match[1]
+		}
+	}
+
+	class, _ := selection.Attr("class")
+	for _, c := range strings.Fields(class) {
+		if colour, ok := p.colours[c]; ok {
+			return colour
+		}
+	}
+	return ""
+}
+
+func classDay(selection *goquery.Selection) int {
+	class, _ := selection.Attr("class")
+	match := dayClassRegex.FindStringSubmatch(class)
+	if match == nil {
+		return 0
+	}
+	day, _ := strconv.Atoi(match[1])
+	return day
+}
@@ -0,0 +1,87 @@
+package puzzle
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// LeaderboardTTL is how long to wait before polling a private leaderboard again
+// https://adventofcode.com/2024/leaderboard/private
+const LeaderboardTTL = 15 * time.Minute
+
+type Leaderboard struct {
+	OwnerID int               `json:"owner_id"`
+	Event   string            `json:"event"`
+	Members map[string]Member `json:"members"`
+}
+
+type Member struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Stars       int    `json:"stars"`
+	LocalScore  int    `json:"local_score"`
+	GlobalScore int    `json:"global_score"`
+	LastStarTs  int64  `json:"last_star_ts"`
+	// Completions maps day -> part -> star
+	Completions map[int]map[int]Star `json:"completion_day_level"`
+}
+
+type Star struct {
+	GetStarTs int64 `json:"get_star_ts"`
+	StarIndex int   `json:"star_index"`
+}
+
+func (s Star) Time() time.Time {
+	return time.Unix(s.GetStarTs, 0)
+}
+
+// DisplayName falls back to the anonymous name used on the site
+func (m Member) DisplayName() string {
+	if m.Name == "" {
+		return fmt.Sprintf("(anonymous user #%d)", m.ID)
+	}
+	return m.Name
+}
+
+// StarsOn returns the number of stars (0, 1 or 2) the member has on day
+func (m Member) StarsOn(day int) int {
+	return len(m.Completions[day])
+}
+
+// Ranked returns members sorted the same way as the site i.e. by local score,
+// then by who got their last star first
+func (l *Leaderboard) Ranked() []Member {
+	var members []Member
+	for _, m := range l.Members {
+		members = append(members, m)
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].LocalScore != members[j].LocalScore {
+			return members[i].LocalScore > members[j].LocalScore
+		}
+		if members[i].LastStarTs != members[j].LastStarTs {
+			return members[i].LastStarTs < members[j].LastStarTs
+		}
+		return members[i].ID < members[j].ID
+	})
+	return members
+}
+
+// Leaderboard gets the private leaderboard with id, responses are cached for LeaderboardTTL
+func (client *Client) Leaderboard(year, id int) (*Leaderboard, error) {
+	path := fmt.Sprintf("%d/leaderboard/private/view/%d.json", year, id)
+
+	bytes, err := client.getCached(path, LeaderboardTTL, validJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboard := new(Leaderboard)
+	if err := json.Unmarshal(bytes, leaderboard); err != nil {
+		return nil, fmt.Errorf("decoding leaderboard %d for %d: %w", id, year, err)
+	}
+	return leaderboard, nil
+}
@@ -0,0 +1,164 @@
+package puzzle
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	fileImport = "github.com/microhod/adventofcode/internal/file"
+	csvImport  = "github.com/microhod/adventofcode/internal/encoding/csv"
+)
+
+// Template suggests which solution template suits the shape
+func (s Shape) Template() string {
+	if s.Kind == ShapeGrid {
+		return "grid"
+	}
+	return "simple"
+}
+
+// Imports are the packages used by Parser
+func (s Shape) Imports() []string {
+	switch s.Kind {
+	case ShapeGrid:
+		return []string{fileImport, "github.com/microhod/adventofcode/internal/geometry/plane"}
+	case ShapeIntRows:
+		if s.Separator == "" {
+			return []string{fileImport, "strconv"}
+		}
+		return []string{fileImport}
+	case ShapeBlocks:
+		return []string{fileImport, "strings"}
+	case ShapeKeyValues:
+		if s.IntValues {
+			return []string{fileImport, csvImport, "strings"}
+		}
+		return []string{fileImport, "strings"}
+	case ShapePattern:
+		return []string{fileImport, "fmt"}
+	default:
+		return []string{fileImport}
+	}
+}
+
+// Parser is the source of a parse function which reads an input of this shape
+// using the helpers in internal/file and internal/encoding/csv
+func (s Shape) Parser() string {
+	switch s.Kind {
+	case ShapeGrid:
+		return `func parse(path string) (map[plane.Vector]byte, error) {
+	return file.ReadVectorMapFunc(path, func(b byte) (byte, error) {
+		return b, nil
+	})
+}`
+	case ShapeInts:
+		separator := s.Separator
+		if separator == "" {
+			separator = ","
+		}
+		return fmt.Sprintf(`func parse(path string) ([]int, error) {
+	return file.ReadCsvInts(path, %q)
+}`, separator)
+	case ShapeIntRows:
+		if s.Separator != "" {
+			return fmt.Sprintf(`func parse(path string) ([][]int, error) {
+	return file.ReadAllCsvInts(path, %q)
+}`, s.Separator)
+		}
+		return `func parse(path string) ([]int, error) {
+	lines, err := file.ReadLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var nums []int
+	for _, line := range lines {
+		n, err := strconv.Atoi(line)
+		if err != nil {
+			return nil, err
+		}
+		nums = append(nums, n)
+	}
+	return nums, nil
+}`
+	case ShapeBlocks:
+		return `func parse(path string) ([]string, error) {
+	input, err := file.Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(strings.TrimSpace(input), "\n\n"), nil
+}`
+	case ShapeKeyValues:
+		if s.IntValues {
+			return `func parse(path string) ([]Entry, error) {
+	lines, err := file.ReadLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, line := range lines {
+		key, value, _ := strings.Cut(line, ": ")
+		values, err := csv.ParseInts(value, " ")
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, Entry{Key: key, Values: values})
+	}
+	return entries, nil
+}
+
+type Entry struct {
+	Key    string
+	Values []int
+}`
+		}
+		return `func parse(path string) ([]Entry, error) {
+	lines, err := file.ReadLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, line := range lines {
+		key, value, _ := strings.Cut(line, ": ")
+		entries = append(entries, Entry{Key: key, Value: value})
+	}
+	return entries, nil
+}
+
+type Entry struct {
+	Key, Value string
+}`
+	case ShapePattern:
+		count := strings.Count(strings.ReplaceAll(s.Format, "%%", ""), "%d")
+		var args []string
+		for i := range count {
+			args = append(args, fmt.Sprintf("&row[%d]", i))
+		}
+		return fmt.Sprintf(`func parse(path string) ([][]int, error) {
+	lines, err := file.ReadLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows [][]int
+	for _, line := range lines {
+		row := make([]int, %d)
+		_, err := fmt.Sscanf(line, %q, %s)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %%q: %%w", line, err)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}`, count, s.Format, strings.Join(args, ", "))
+	default:
+		return `func parse(path string) ([]string, error) {
+	return file.ReadLines(path)
+}`
+	}
+}
@@ -1,9 +1,14 @@
 package puzzle
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"regexp"
 	"strings"
 	"time"
 
@@ -21,31 +26,70 @@ var (
 	lastRequest = time.Now()
 )
 
+// Days returns the number of puzzles in the event for year, from 2025 there are only 12
+func Days(year int) int {
+	if year >= 2025 {
+		return 12
+	}
+	return 25
+}
+
 type Puzzle struct {
+	Year, Day int
 	Name      string
 	Readme    string
 	TestInput string
 	Input     string
+	// Examples has an example for each part which is unlocked
+	Examples []Example
+}
+
+// Example is the example input for a part, along with the answer if it could
+// be found in the puzzle text
+type Example struct {
+	Part   int
+	Input  string
+	Answer string
 }
 
 type Client struct {
 	httpClient        *http.Client
 	markdownConverter *md.Converter
+	cache             *cache
 	token             string
 }
 
-func NewClient(token string) *Client {
+type ClientOption func(*Client)
+
+// WithCacheDir sets the folder used to cache responses, an empty dir disables caching
+func WithCacheDir(dir string) ClientOption {
+	return func(c *Client) {
+		if dir == "" {
+			c.cache = nil
+			return
+		}
+		c.cache = newCache(dir)
+	}
+}
+
+func NewClient(token string, options ...ClientOption) *Client {
 	converter := md.NewConverter("adventofcode.com", true, &md.Options{
 		CodeBlockStyle: "fenced",
 	})
 
-	converter.Use(markdown.AdventOfCode())
+	// READMEs are stored in the archive at YEAR/DAY relative to where aoc runs
+	converter.Use(markdown.AdventOfCode(markdown.WithArchive(".")))
 	
-	return &Client{
+	client := &Client{
 		httpClient:        http.DefaultClient,
 		markdownConverter: converter,
+		cache:             newCache(DefaultCacheDir()),
 		token:             token,
 	}
+	for _, o := range options {
+		o(client)
+	}
+	return client
 }
 
 func (client *Client) Get(year, day int) (*Puzzle, error) {
@@ -60,9 +104,12 @@ func (client *Client) Get(year, day int) (*Puzzle, error) {
 	}
 
 	return &Puzzle{
+		Year: year,
+		Day: day,
 		Name: client.getName(html),
 		Readme: client.getREADME(html),
 		TestInput: client.getTestInput(html),
+		Examples: client.getExamples(html),
 		Input: input,
 	}, nil
 }
@@ -81,6 +128,17 @@ func (client *Client) getHTML(year, day int) (*goquery.Selection, error) {
 		return nil, err
 	}
 
+	// resolve relative links e.g. `6` means day 6 of the same year
+	base, err := url.Parse(fmt.Sprintf("%s/%s", baseURL, path))
+	if err != nil {
+		return nil, err
+	}
+	doc.Find("a[href]").Each(func(i int, a *goquery.Selection) {
+		if href, err := url.Parse(a.AttrOr("href", "")); err == nil {
+			a.SetAttr("href", base.ResolveReference(href).String())
+		}
+	})
+
 	// the 'article' tags are the actual puzzle information
 	return doc.Find("article"), nil
 }
@@ -109,6 +167,68 @@ func (client *Client) getTestInput(html *goquery.Selection) string {
 	return strings.TrimSpace(input)
 }
 
+func (client *Client) getExamples(html *goquery.Selection) []Example {
+	var examples []Example
+	// each part is in its own 'article' tag
+	html.Each(func(i int, article *goquery.Selection) {
+		example := Example{Part: i + 1}
+
+		// later parts often reuse the earlier example
+		example.Input = strings.TrimSpace(article.Find("pre").First().Text())
+		if example.Input == "" && i > 0 {
+			example.Input = examples[i-1].Input
+		}
+
+		example.Answer = exampleAnswer(article)
+
+		examples = append(examples, example)
+	})
+	return examples
+}
+
+// answerPhrasing is how the puzzles describe the answer to the example e.g.
+// "In this example, ... a total of <code><em>26</em></code>."
+var answerPhrasing = regexp.MustCompile(`(?i)\bexample\b|\b(adding|produces?|gives|total|sum)\b`)
+
+// exampleAnswer is the last highlighted code e.g. <code><em>480</em></code> in a
+// sentence which describes the answer, or empty if there isn't one so the test
+// is left as a TODO
+func exampleAnswer(article *goquery.Selection) string {
+	var answer string
+	article.Find("code > em").Each(func(_ int, em *goquery.Selection) {
+		if answerPhrasing.MatchString(sentence(em)) {
+			answer = strings.TrimSpace(em.Text())
+		}
+	})
+	return answer
+}
+
+// sentence is the text of the sentence in the paragraph around the selection
+func sentence(s *goquery.Selection) string {
+	paragraph := s.Closest("p, li")
+	if paragraph.Length() == 0 {
+		return ""
+	}
+
+	// mark where s is, since its text could be anywhere in the paragraph
+	const marker = "\uE000"
+	index := paragraph.Find("code > em").IndexOfSelection(s)
+	clone := paragraph.Clone()
+	clone.Find("code > em").Eq(index).ReplaceWithHtml(marker)
+
+	text := clone.Text()
+	at := strings.Index(text, marker)
+	if at < 0 {
+		return ""
+	}
+	before, after := text[:at], text[at+len(marker):]
+	before = before[strings.LastIndexAny(before, ".!?")+1:]
+	if end := strings.IndexAny(after, ".!?"); end >= 0 {
+		after = after[:end]
+	}
+	return before + s.Text() + after
+}
+
 func (client *Client) getInput(year, day int) (string, error) {
 	path := fmt.Sprintf("%d/day/%d/input", year, day)
 
@@ -126,6 +246,60 @@ func (client *Client) getInput(year, day int) (string, error) {
 	return string(bytes), err
 }
 
+// userAgent identifies requests from aoc, as asked of automated tools
+// https://www.reddit.com/r/adventofcode/wiki/faqs/automation
+const userAgent = "github.com/microhod/adventofcode"
+
+// ErrLoggedOut is returned when adventofcode.com answers as if there's no
+// session, which is usually because the token has expired
+var ErrLoggedOut = errors.New("not logged in to adventofcode.com, the token may have expired")
+
+// validJSON checks a body which should be JSON, when logged out the site
+// serves a HTML page instead
+func validJSON(body []byte) error {
+	if !json.Valid(body) {
+		return ErrLoggedOut
+	}
+	return nil
+}
+
+// loggedInHTML checks a page was served to a logged in user, who always has a
+// link to log out in the header
+func loggedInHTML(body []byte) error {
+	if !bytes.Contains(body, []byte("/auth/logout")) {
+		return ErrLoggedOut
+	}
+	return nil
+}
+
+// getCached returns the body at path, only making a request if the cached
+// copy is older than ttl, the body is only cached if valid accepts it so that
+// e.g. a logged out page isn't reused
+func (client *Client) getCached(path string, ttl time.Duration, valid func([]byte) error) ([]byte, error) {
+	if body, ok := client.cache.get(path, ttl); ok {
+		return body, nil
+	}
+
+	resp, err := client.get(path)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := valid(body); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := client.cache.put(path, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
 func (client *Client) get(path string) (*http.Response, error) {
 	url := fmt.Sprintf("%s/%s", baseURL, path)
 
@@ -152,6 +326,7 @@ func (client *Client) do(req *http.Request) (*http.Response, error) {
 		time.Sleep(time.Second)
 	}
 
+	req.Header.Set("User-Agent", userAgent)
 	req.AddCookie(&http.Cookie{
 		Name:  "session",
 		Value: client.token,
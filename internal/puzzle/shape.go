@@ -0,0 +1,245 @@
+package puzzle
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type ShapeKind string
+
+const (
+	ShapeEmpty ShapeKind = "empty"
+	// ShapeGrid is a rectangle of characters e.g. a map
+	ShapeGrid ShapeKind = "grid"
+	// ShapeInts is a single line of separated integers
+	ShapeInts ShapeKind = "ints"
+	// ShapeIntRows is many lines of separated integers
+	ShapeIntRows ShapeKind = "int rows"
+	// ShapeBlocks is sections separated by blank lines
+	ShapeBlocks ShapeKind = "blocks"
+	// ShapeKeyValues is lines like `key: value`
+	ShapeKeyValues ShapeKind = "key values"
+	// ShapePattern is lines which only differ by the numbers in them
+	ShapePattern ShapeKind = "pattern"
+	ShapeLines   ShapeKind = "lines"
+)
+
+// Shape is a rough description of what a puzzle input looks like
+type Shape struct {
+	Kind          ShapeKind
+	Width, Height int
+	// Chars are the distinct characters in a grid, most common first
+	Chars []byte
+	// Separator between integers
+	Separator string
+	// Blocks is the number of blank line separated sections
+	Blocks int
+	// Format is a fmt.Sscanf format which matches every line of a pattern
+	Format string
+	// IntValues is true if the values of key: value lines are all integers
+	IntValues bool
+}
+
+// DetectShape guesses the shape of the input
+func DetectShape(input string) Shape {
+	input = strings.TrimRight(input, "\n")
+	if strings.TrimSpace(input) == "" {
+		return Shape{Kind: ShapeEmpty}
+	}
+
+	lines := strings.Split(input, "\n")
+	shape := Shape{Kind: ShapeLines, Height: len(lines)}
+	for _, line := range lines {
+		shape.Width = max(shape.Width, len(line))
+	}
+
+	switch {
+	case strings.Contains(input, "\n\n"):
+		shape.Kind = ShapeBlocks
+		shape.Blocks = len(strings.Split(input, "\n\n"))
+	case len(lines) > 1 && isGrid(lines):
+		shape.Kind = ShapeGrid
+		shape.Chars = gridChars(lines)
+	case allInts(lines, ""):
+		shape.Kind = ShapeIntRows
+	case allInts(lines, ","):
+		shape.Kind, shape.Separator = ShapeIntRows, ","
+	case allInts(lines, " "):
+		shape.Kind, shape.Separator = ShapeIntRows, " "
+	case isKeyValues(lines):
+		shape.Kind = ShapeKeyValues
+		shape.IntValues = true
+		for _, line := range lines {
+			_, value, _ := strings.Cut(line, ":")
+			shape.IntValues = shape.IntValues && allInts([]string{value}, " ")
+		}
+	case len(lines) > 1:
+		if format, ok := commonFormat(lines); ok {
+			shape.Kind, shape.Format = ShapePattern, format
+		}
+	}
+
+	if shape.Kind == ShapeIntRows && len(lines) == 1 {
+		shape.Kind = ShapeInts
+	}
+	return shape
+}
+
+// isGrid is true if every line is the same length and has no spaces, but not
+// for lists of short numbers or words which just happen to be the same length,
+// or rows of comma separated integers like `2,2,2`
+func isGrid(lines []string) bool {
+	for _, line := range lines {
+		if len(line) != len(lines[0]) || len(line) < 2 || strings.ContainsAny(line, " \t") {
+			return false
+		}
+	}
+	if strings.Contains(lines[0], ",") && allInts(lines, ",") {
+		return false
+	}
+
+	width := len(lines[0])
+	if allInts(lines, "") && width < 5 {
+		return false
+	}
+	return len(lines) <= 4*width || len(gridChars(lines)) <= 4
+}
+
+func gridChars(lines []string) []byte {
+	counts := map[byte]int{}
+	for _, line := range lines {
+		for i := range line {
+			counts[line[i]]++
+		}
+	}
+
+	var chars []byte
+	for ch := range counts {
+		chars = append(chars, ch)
+	}
+	sort.Slice(chars, func(i, j int) bool {
+		if counts[chars[i]] != counts[chars[j]] {
+			return counts[chars[i]] > counts[chars[j]]
+		}
+		return chars[i] < chars[j]
+	})
+	return chars
+}
+
+// allInts is true if every line only contains integers split by separator, an
+// empty separator means one integer per line
+func allInts(lines []string, separator string) bool {
+	for _, line := range lines {
+		fields := []string{line}
+		if separator != "" {
+			fields = strings.Split(line, separator)
+		}
+
+		var count int
+		for _, field := range fields {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			if _, err := strconv.Atoi(field); err != nil {
+				return false
+			}
+			count++
+		}
+		if count == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isKeyValues is true for lines like `key: value`, where the key is one word
+func isKeyValues(lines []string) bool {
+	for _, line := range lines {
+		key, _, found := strings.Cut(line, ": ")
+		if !found || key == "" || strings.ContainsAny(key, " \t") {
+			return false
+		}
+	}
+	return true
+}
+
+// commonFormat finds a format which matches all the lines, if they are the same
+// apart from the integers in them e.g. `Sensor at x=%d, y=%d`
+func commonFormat(lines []string) (string, bool) {
+	common := format(lines[0])
+	if !strings.Contains(common, "%d") {
+		return "", false
+	}
+	for _, line := range lines[1:] {
+		if format(line) != common {
+			return "", false
+		}
+	}
+	return common, true
+}
+
+// format replaces the integers in line with %d, a '-' is only treated as a
+// sign if it isn't between two numbers e.g. the ranges in `2-4,6-8`
+func format(line string) string {
+	builder := new(strings.Builder)
+	for i := 0; i < len(line); i++ {
+		isDigit := func(j int) bool { return j >= 0 && j < len(line) && unicode.IsDigit(rune(line[j])) }
+
+		start := i
+		if line[i] == '-' && isDigit(i+1) && !isDigit(i-1) {
+			i++
+		}
+		if !isDigit(i) {
+			if line[start] == '%' {
+				builder.WriteByte('%')
+			}
+			builder.WriteByte(line[start])
+			i = start
+			continue
+		}
+
+		for isDigit(i + 1) {
+			i++
+		}
+		builder.WriteString("%d")
+	}
+	return builder.String()
+}
+
+func (s Shape) String() string {
+	switch s.Kind {
+	case ShapeGrid:
+		var chars []string
+		for _, ch := range s.Chars {
+			chars = append(chars, string(ch))
+		}
+		return fmt.Sprintf("%dx%d grid of %s", s.Width, s.Height, strings.Join(chars, " "))
+	case ShapeInts:
+		if s.Separator == "" {
+			return "a single integer"
+		}
+		return fmt.Sprintf("a single line of integers separated by %q", s.Separator)
+	case ShapeIntRows:
+		if s.Separator == "" {
+			return fmt.Sprintf("%d lines of one integer", s.Height)
+		}
+		return fmt.Sprintf("%d lines of integers separated by %q", s.Height, s.Separator)
+	case ShapeBlocks:
+		return fmt.Sprintf("%d blocks separated by blank lines", s.Blocks)
+	case ShapeKeyValues:
+		return fmt.Sprintf("%d lines of `key: value`", s.Height)
+	case ShapePattern:
+		return fmt.Sprintf("%d lines like %q", s.Height, s.Format)
+	case ShapeLines:
+		if s.Height == 1 {
+			return fmt.Sprintf("a single line of %d characters", s.Width)
+		}
+		return fmt.Sprintf("%d lines", s.Height)
+	default:
+		return "nothing"
+	}
+}
@@ -0,0 +1,35 @@
+package puzzle
+
+import "testing"
+
+func TestDetectShape(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		kind      ShapeKind
+		separator string
+	}{
+		{name: "empty", input: "\n", kind: ShapeEmpty},
+		{name: "grid", input: "#..#\n.##.\n#..#\n", kind: ShapeGrid},
+		{name: "digit grid", input: "30373\n25512\n65332\n", kind: ShapeGrid},
+		{name: "short digit rows", input: "1\n22\n3\n", kind: ShapeIntRows},
+		{name: "comma pairs", input: "1,2\n3,4\n", kind: ShapeIntRows, separator: ","},
+		{name: "comma triples", input: "2,2,2\n1,2,2\n3,2,2\n", kind: ShapeIntRows, separator: ","},
+		{name: "negative comma pairs", input: "-1,2\n3,-4\n", kind: ShapeIntRows, separator: ","},
+		{name: "space rows", input: "7 6 4\n1 2 7\n", kind: ShapeIntRows, separator: " "},
+		{name: "single line", input: "3,4,3,1,2\n", kind: ShapeInts, separator: ","},
+		{name: "blocks", input: "1\n2\n\n3\n", kind: ShapeBlocks},
+		{name: "key values", input: "Time: 7 15\nDistance: 9 40\n", kind: ShapeKeyValues},
+		{name: "pattern", input: "Sensor at x=2, y=18\nSensor at x=-9, y=16\n", kind: ShapePattern},
+		{name: "lines", input: "hello world\nfoo\n", kind: ShapeLines},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			shape := DetectShape(test.input)
+			if shape.Kind != test.kind || shape.Separator != test.separator {
+				t.Errorf("got %s with separator %q, want %s with separator %q", shape.Kind, shape.Separator, test.kind, test.separator)
+			}
+		})
+	}
+}
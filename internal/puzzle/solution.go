@@ -2,74 +2,96 @@
 package puzzle
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
-	"strings"
-	"text/template"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/mgutz/ansi"
 	"github.com/microhod/adventofcode/internal/christmas"
+	"github.com/microhod/adventofcode/internal/visual"
 )
 
 var (
 	BoldRed   = ansi.ColorFunc("red+bh")
 	BoldGreen = ansi.ColorFunc("green+bh")
-
-	templateSolutionFile = 
-`package main
-
-import (
-	"fmt"
-
-	"github.com/microhod/adventofcode/internal/file"
-	"github.com/microhod/adventofcode/internal/puzzle"
 )
 
-const (
-	InputFile = "input.txt"
-	TestFile  = "test.txt"
-)
-
-func main() {
-	puzzle.NewSolution("{{.Name}}", part1, part2).Run()
+type Solution struct {
+	Name  string
+	Parts []func() error
 }
 
-func part1() error {
-	return nil
+func NewSolution(name string, parts ...func() error) *Solution {
+	return &Solution{Name: name, Parts: parts}
 }
 
-func part2() error {
-	return nil
-}
-`
-)
+// inputOverride is set by the -input flag of Solution.Run
+var inputOverride string
 
-func InitialSolutionFile(puzzle *Puzzle) (string, error) {
-	tmpl, err := template.New("").Parse(templateSolutionFile)
-	if err != nil {
-		return "", err
-	}
-	
-	builder := new(strings.Builder)
-	err = tmpl.Execute(builder, puzzle)
-	if err != nil {
-		return "", err
-	}
+// Answer turns a part which returns its answer for the input at path into
+// one which can be run by a Solution, path is replaced by the -input flag
+func Answer[T any](part func(path string) (T, error), path string) func() error {
+	return func() error {
+		if inputOverride != "" {
+			path = inputOverride
+		}
 
-	return builder.String(), nil
+		answer, err := part(path)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("answer: %v\n", answer)
+		return nil
+	}
 }
 
-type Solution struct {
-	Name  string
-	Parts []func() error
+// Timing is how long a part of a solution took to run
+type Timing struct {
+	Part    int           `json:"part"`
+	Elapsed time.Duration `json:"elapsed"`
 }
 
-func NewSolution(name string, parts ...func() error) *Solution {
-	return &Solution{Name: name, Parts: parts}
+// ReadTimings reads timings written by running a solution with -timings
+func ReadTimings(path string) ([]Timing, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var timings []Timing
+	err = json.Unmarshal(bytes, &timings)
+	return timings, err
 }
 
+// Run runs each part in turn, it accepts the flags:
+//
+//	-timings FILE   write the time taken by each part as json to FILE
+//	-part N         only run part N
+//	-input FILE     run parts created with Answer against FILE
+//	-record DIR     write the frames recorded by each part to DIR/partN.gif
+//	-play           play the frames recorded by each part in the terminal
+//	-fps N          the frame rate of recordings
 func (s *Solution) Run() {
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	timingsFile := flags.String("timings", "", "write the time taken by each part as json to this file")
+	only := flags.Int("part", 0, "only run this part")
+	flags.StringVar(&inputOverride, "input", "", "run parts created with Answer against this file")
+	record := flags.String("record", "", "write the frames recorded by each part as gifs to this folder")
+	play := flags.Bool("play", false, "play the frames recorded by each part in the terminal")
+	fps := flags.Int("fps", 10, "the frame rate of recordings")
+	flags.Parse(os.Args[1:])
+
+	if *fps <= 0 {
+		log.Fatalf("fps must be positive, got: %d", *fps)
+	}
+	if *record != "" || *play {
+		visual.Enable()
+	}
+
 	// disable timstamps for logging
 	log.SetFlags(0)
 
@@ -86,12 +108,18 @@ func (s *Solution) Run() {
 
 	log.Println()
 
+	var timings []Timing
 	for i, part := range s.Parts {
+		if *only != 0 && *only != i+1 {
+			continue
+		}
+
 		// Print part number
 		log.Println(BoldRed(fmt.Sprintf("Part %d", i+1)))
 		log.Println()
 
 		// run part
+		visual.Reset()
 		start := time.Now()
 		err := part()
 		elapsed := time.Since(start)
@@ -103,5 +131,31 @@ func (s *Solution) Run() {
 		log.Println()
 		log.Printf("⏰ %s", elapsed)
 		log.Println()
+
+		timings = append(timings, Timing{Part: i + 1, Elapsed: elapsed})
+
+		if frames := visual.Frames(); len(frames) > 0 {
+			if *play {
+				visual.Play(os.Stdout, frames, *fps)
+			}
+			if *record != "" {
+				path := filepath.Join(*record, fmt.Sprintf("part%d.gif", i+1))
+				if err := visual.SaveGIF(path, frames, *fps, 4); err != nil {
+					log.Fatal(err)
+				}
+				log.Printf("🎥 %d frames saved to %s", len(frames), path)
+				log.Println()
+			}
+		}
+	}
+
+	if *timingsFile != "" {
+		bytes, err := json.MarshalIndent(timings, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(*timingsFile, bytes, 0644); err != nil {
+			log.Fatal(err)
+		}
 	}
 }
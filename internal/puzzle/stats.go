@@ -0,0 +1,131 @@
+package puzzle
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// DayStats is a row from the personal leaderboard statistics page
+type DayStats struct {
+	Day          int
+	Part1, Part2 PartStats
+}
+
+type PartStats struct {
+	// Time taken from the puzzle unlocking, only set if it was within 24 hours
+	Time    time.Duration
+	Over24h bool
+	Rank    int
+	Score   int
+}
+
+func (p PartStats) Done() bool {
+	return p.Time > 0 || p.Over24h
+}
+
+func (p PartStats) String() string {
+	switch {
+	case p.Over24h:
+		return ">24h"
+	case p.Done():
+		return p.Time.String()
+	default:
+		return "-"
+	}
+}
+
+// PersonalStats gets the time, rank and score for each part completed in year
+func (client *Client) PersonalStats(year int) ([]DayStats, error) {
+	path := fmt.Sprintf("%d/leaderboard/self", year)
+
+	body, err := client.getCached(path, LeaderboardTTL, loggedInHTML)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	return parsePersonalStats(doc.Find("article pre").First().Text())
+}
+
+// parsePersonalStats parses the table of stats e.g.
+//
+//	      --------Part 1--------   --------Part 2--------
+//	Day       Time   Rank  Score       Time   Rank  Score
+//	  2   00:14:41    738      0       >24h  12112      0
+//	  1   00:04:03    301      0   00:06:40    257      0
+//
+// later years don't have ranks and scores, so only the times are parsed
+func parsePersonalStats(table string) ([]DayStats, error) {
+	var stats []DayStats
+	for _, line := range strings.Split(table, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		day, err := strconv.Atoi(fields[0])
+		if err != nil {
+			// header line
+			continue
+		}
+
+		// each part has the same number of columns
+		width := (len(fields) - 1) / 2
+		if width < 1 {
+			width = 1
+		}
+
+		row := DayStats{Day: day}
+		row.Part1, err = parsePartStats(fields[1 : 1+width])
+		if err != nil {
+			return nil, fmt.Errorf("day %d part 1: %w", day, err)
+		}
+		if len(fields) >= 1+2*width {
+			row.Part2, err = parsePartStats(fields[1+width : 1+2*width])
+			if err != nil {
+				return nil, fmt.Errorf("day %d part 2: %w", day, err)
+			}
+		}
+		stats = append(stats, row)
+	}
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Day < stats[j].Day })
+	return stats, nil
+}
+
+func parsePartStats(fields []string) (PartStats, error) {
+	var stats PartStats
+	switch fields[0] {
+	case "-":
+		return stats, nil
+	case ">24h":
+		stats.Over24h = true
+	default:
+		var h, m, s int
+		if _, err := fmt.Sscanf(fields[0], "%d:%d:%d", &h, &m, &s); err != nil {
+			return stats, fmt.Errorf("parsing time %q: %w", fields[0], err)
+		}
+		stats.Time = time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+	}
+
+	var err error
+	if len(fields) > 1 && fields[1] != "-" {
+		if stats.Rank, err = strconv.Atoi(fields[1]); err != nil {
+			return stats, err
+		}
+	}
+	if len(fields) > 2 && fields[2] != "-" {
+		if stats.Score, err = strconv.Atoi(fields[2]); err != nil {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,115 @@
+package puzzle
+
+import (
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/microhod/adventofcode/internal/set"
+)
+
+// TemplateExtension is the extension of solution template files e.g. grid.go.tmpl
+const TemplateExtension = ".go.tmpl"
+
+// TemplateData is passed to the solution templates
+type TemplateData struct {
+	Year, Day int
+	Name      string
+	Example   string
+	Shape     Shape
+	// Examples has one test case for each part
+	Examples []TemplateExample
+}
+
+type TemplateExample struct {
+	Example
+	// File the example input is written to
+	File string
+}
+
+func NewTemplateData(puzzle *Puzzle) TemplateData {
+	data := TemplateData{
+		Year:    puzzle.Year,
+		Day:     puzzle.Day,
+		Name:    puzzle.Name,
+		Example: strings.Trim(puzzle.TestInput, "\n"),
+		Shape:   DetectShape(puzzle.Input),
+	}
+	for _, example := range puzzle.Examples {
+		data.Examples = append(data.Examples, TemplateExample{Example: example})
+	}
+	// part 2 is locked until part 1 is solved, so assume it uses the same example
+	for part := len(data.Examples) + 1; part <= 2; part++ {
+		data.Examples = append(data.Examples, TemplateExample{Example: Example{Part: part, Input: puzzle.TestInput}})
+	}
+	return data
+}
+
+var templateFuncs = template.FuncMap{
+	// comment turns text into an indented go comment
+	"comment": func(text string) string {
+		lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+		for i := range lines {
+			lines[i] = strings.TrimRight("//\t"+lines[i], " \t")
+		}
+		return strings.Join(lines, "\n")
+	},
+	// imports renders a gofmt style import block, with the standard library first
+	"imports": func(paths []string, extra ...string) string {
+		var std, other []string
+		for _, path := range set.NewSet(append(paths, extra...)...).ToSlice() {
+			if strings.Contains(path, ".") {
+				other = append(other, fmt.Sprintf("\t%q", path))
+			} else {
+				std = append(std, fmt.Sprintf("\t%q", path))
+			}
+		}
+		sort.Strings(std)
+		sort.Strings(other)
+
+		groups := []string{}
+		for _, group := range [][]string{std, other} {
+			if len(group) > 0 {
+				groups = append(groups, strings.Join(group, "\n"))
+			}
+		}
+		return fmt.Sprintf("import (\n%s\n)", strings.Join(groups, "\n\n"))
+	},
+	// head returns the first n lines of text
+	"head": func(n int, text string) string {
+		lines := strings.Split(text, "\n")
+		if len(lines) > n {
+			lines = append(lines[:n], "...")
+		}
+		return strings.Join(lines, "\n")
+	},
+}
+
+// LoadTemplate reads the template called name e.g. grid for grid.go.tmpl, from
+// the first of the filesystems which contains it
+func LoadTemplate(name string, filesystems ...fs.FS) (string, error) {
+	for _, fsys := range filesystems {
+		bytes, err := fs.ReadFile(fsys, name+TemplateExtension)
+		if err == nil {
+			return string(bytes), nil
+		}
+	}
+	return "", fmt.Errorf("no template found called %s", name)
+}
+
+func InitialSolutionFile(text string, data TemplateData) (string, error) {
+	tmpl, err := template.New("").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	builder := new(strings.Builder)
+	err = tmpl.Execute(builder, data)
+	if err != nil {
+		return "", err
+	}
+
+	return builder.String(), nil
+}
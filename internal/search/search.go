@@ -0,0 +1,187 @@
+// Package search is a full text search over documents e.g. the puzzle descriptions
+//
+// documents are ranked with BM25 https://en.wikipedia.org/wiki/Okapi_BM25
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/agnivade/levenshtein"
+)
+
+const (
+	// k1 and b are the usual BM25 tuning parameters
+	k1 = 1.2
+	b  = 0.75
+)
+
+type Document struct {
+	Year, Day int
+	Name      string
+	Text      string
+}
+
+type Result struct {
+	Document Document
+	Score    float64
+	// Terms are the terms in the document which matched the query
+	Terms []string
+}
+
+// Index is an inverted index from each term to the documents containing it
+type Index struct {
+	docs []Document
+	// postings maps term -> document -> number of times the term appears
+	postings map[string]map[int]int
+	lengths  []int
+	average  float64
+}
+
+func NewIndex(docs []Document) *Index {
+	index := &Index{docs: docs, postings: map[string]map[int]int{}}
+
+	var total int
+	for i, doc := range docs {
+		terms := Tokenize(doc.Name + " " + doc.Text)
+		for _, term := range terms {
+			if index.postings[term] == nil {
+				index.postings[term] = map[int]int{}
+			}
+			index.postings[term][i]++
+		}
+		index.lengths = append(index.lengths, len(terms))
+		total += len(terms)
+	}
+	if len(docs) > 0 {
+		index.average = float64(total) / float64(len(docs))
+	}
+	return index
+}
+
+// Tokenize splits text into lower case words
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Search ranks the documents against the query, if fuzzy is true then query
+// terms also match terms within a small edit distance, weighted by how close
+// they are
+func (index *Index) Search(query string, fuzzy bool) []Result {
+	scores := map[int]float64{}
+	matched := map[int][]string{}
+
+	for _, term := range Tokenize(query) {
+		for match, weight := range index.expand(term, fuzzy) {
+			idf := index.idf(match)
+			for doc, tf := range index.postings[match] {
+				norm := float64(tf) * (k1 + 1) / (float64(tf) + k1*(1-b+b*float64(index.lengths[doc])/index.average))
+				scores[doc] += weight * idf * norm
+				matched[doc] = append(matched[doc], match)
+			}
+		}
+	}
+
+	var results []Result
+	for doc, score := range scores {
+		results = append(results, Result{Document: index.docs[doc], Score: score, Terms: matched[doc]})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Document.Year != results[j].Document.Year {
+			return results[i].Document.Year < results[j].Document.Year
+		}
+		return results[i].Document.Day < results[j].Document.Day
+	})
+	return results
+}
+
+// expand returns the terms in the index which match term, along with how much
+// each match should count
+func (index *Index) expand(term string, fuzzy bool) map[string]float64 {
+	matches := map[string]float64{}
+	if _, ok := index.postings[term]; ok {
+		matches[term] = 1
+	}
+	if !fuzzy {
+		return matches
+	}
+
+	// allow more typos in longer words
+	maxDistance := len(term) / 4
+	for candidate := range index.postings {
+		if candidate == term || math.Abs(float64(len(candidate)-len(term))) > float64(maxDistance) {
+			continue
+		}
+		if d := levenshtein.ComputeDistance(term, candidate); d <= maxDistance {
+			matches[candidate] = 1 / float64(1+d)
+		}
+	}
+	return matches
+}
+
+func (index *Index) idf(term string) float64 {
+	n := float64(len(index.postings[term]))
+	return math.Log(1 + (float64(len(index.docs))-n+0.5)/(n+0.5))
+}
+
+// Snippet returns the text around the first of the terms found in text
+func Snippet(text string, terms []string, width int) string {
+	lower := strings.ToLower(text)
+
+	start := -1
+	for _, term := range terms {
+		if i := indexWord(lower, term); i >= 0 && (start < 0 || i < start) {
+			start = i
+		}
+	}
+	if start < 0 {
+		start = 0
+	}
+
+	from := min(len(text), max(0, start-width/2))
+	to := min(len(text), from+width)
+	// move back to the start of a character rather than cutting one in half
+	for from > 0 && from < len(text) && !utf8.RuneStart(text[from]) {
+		from--
+	}
+	for to < len(text) && !utf8.RuneStart(text[to]) {
+		to--
+	}
+	snippet := strings.Join(strings.Fields(text[from:to]), " ")
+
+	if from > 0 {
+		snippet = "..." + snippet
+	}
+	if to < len(text) {
+		snippet += "..."
+	}
+	return snippet
+}
+
+// indexWord finds term in text as a whole word
+func indexWord(text, term string) int {
+	isWord := func(i int) bool {
+		return i >= 0 && i < len(text) && (unicode.IsLetter(rune(text[i])) || unicode.IsDigit(rune(text[i])))
+	}
+
+	for offset := 0; offset < len(text); {
+		i := strings.Index(text[offset:], term)
+		if i < 0 {
+			return -1
+		}
+		i += offset
+		if !isWord(i-1) && !isWord(i+len(term)) {
+			return i
+		}
+		offset = i + 1
+	}
+	return -1
+}
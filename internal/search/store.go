@@ -0,0 +1,51 @@
+package search
+
+import (
+	"encoding/gob"
+	"os"
+	"path/filepath"
+)
+
+// stored is an Index as it's saved, with the key of the documents it was built
+// from
+type stored struct {
+	Key      string
+	Docs     []Document
+	Postings map[string]map[int]int
+	Lengths  []int
+	Average  float64
+}
+
+// Load reads the index saved at path, ok is false if there isn't one or it was
+// saved with a different key i.e. the documents have changed since
+func Load(path, key string) (*Index, bool) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, false
+	}
+	defer file.Close()
+
+	var s stored
+	if err := gob.NewDecoder(file).Decode(&s); err != nil || s.Key != key {
+		return nil, false
+	}
+	return &Index{docs: s.Docs, postings: s.Postings, lengths: s.Lengths, average: s.Average}, true
+}
+
+// Save writes the index to path so it can be loaded with the same key
+func (index *Index) Save(path, key string) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	s := stored{Key: key, Docs: index.docs, Postings: index.postings, Lengths: index.lengths, Average: index.average}
+	if err := gob.NewEncoder(file).Encode(s); err != nil {
+		return err
+	}
+	return file.Close()
+}
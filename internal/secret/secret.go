@@ -0,0 +1,114 @@
+// Package secret encrypts files which shouldn't be published in plain text
+// e.g. puzzle inputs https://adventofcode.com/about#faq_copying
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/microhod/adventofcode/internal/config"
+)
+
+// Extension is added to the name of encrypted files e.g. input.txt.enc
+const Extension = ".enc"
+
+var ErrNoKey = errors.New("no input key configured")
+
+// NewKey generates a random AES-256 key, hex encoded for the config file
+func NewKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(key), nil
+}
+
+// Key loads the key from the user's config
+func Key() ([]byte, error) {
+	c, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if c.InputKey == "" {
+		return nil, fmt.Errorf("%w: set %s or input_key in %s", ErrNoKey, config.InputKeyEnv, config.Path())
+	}
+
+	key, err := hex.DecodeString(c.InputKey)
+	if err != nil {
+		return nil, fmt.Errorf("decoding input key: %w", err)
+	}
+	return key, nil
+}
+
+// ReadFile decrypts the encrypted copy of path i.e. path + Extension
+func ReadFile(path string) ([]byte, error) {
+	ciphertext, err := os.ReadFile(path + Extension)
+	if err != nil {
+		return nil, err
+	}
+	key, err := Key()
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := Decrypt(key, ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting %s: %w", path+Extension, err)
+	}
+	return plaintext, nil
+}
+
+// WriteFile writes an encrypted copy of plaintext to path + Extension
+func WriteFile(path string, plaintext []byte) error {
+	key, err := Key()
+	if err != nil {
+		return err
+	}
+
+	ciphertext, err := Encrypt(key, plaintext)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path+Extension, ciphertext, 0644)
+}
+
+// Encrypt uses AES-GCM, the random nonce is prepended to the cipher text
+func Encrypt(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt reverses Encrypt
+func Decrypt(key, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("cipher text is too short")
+	}
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,35 @@
+package site
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/microhod/adventofcode/internal/puzzle"
+)
+
+// Calendar renders the calendar art, days link to their page if they are in
+// the site and days without stars are greyed out
+func Calendar(cal *puzzle.Calendar, linked map[int]bool) template.HTML {
+	builder := new(strings.Builder)
+	builder.WriteString(`<pre class="calendar">`)
+	for _, line := range cal.Lines {
+		for _, span := range line {
+			text := template.HTMLEscapeString(span.Text)
+
+			switch {
+			case span.Day > 0 && cal.Stars[span.Day] == 0:
+				text = `<span class="locked">` + text + `</span>`
+			case span.Colour != "":
+				text = fmt.Sprintf(`<span style="color: %s">%s</span>`, template.HTMLEscapeString(span.Colour), text)
+			}
+			if span.Day > 0 && linked[span.Day] {
+				text = fmt.Sprintf(`<a href="%02d/index.html">%s</a>`, span.Day, text)
+			}
+			builder.WriteString(text)
+		}
+		builder.WriteString("\n")
+	}
+	builder.WriteString("</pre>")
+	return template.HTML(builder.String())
+}
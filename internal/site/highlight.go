@@ -0,0 +1,63 @@
+package site
+
+import (
+	"go/scanner"
+	"go/token"
+	"html/template"
+	"strings"
+)
+
+// HighlightGo wraps the tokens of Go source in spans with a class for each
+// kind of token, the gaps between tokens e.g. whitespace are kept as they are
+func HighlightGo(src []byte) template.HTML {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+
+	var s scanner.Scanner
+	s.Init(file, src, nil, scanner.ScanComments)
+
+	builder := new(strings.Builder)
+	var offset int
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		// automatically inserted semicolons aren't in the source
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue
+		}
+
+		start := file.Offset(pos)
+		end := start + len(tok.String())
+		if lit != "" {
+			end = start + len(lit)
+		}
+		builder.WriteString(template.HTMLEscapeString(string(src[offset:start])))
+
+		text := template.HTMLEscapeString(string(src[start:end]))
+		if class := tokenClass(tok); class != "" {
+			builder.WriteString(`<span class="` + class + `">` + text + `</span>`)
+		} else {
+			builder.WriteString(text)
+		}
+		offset = end
+	}
+	builder.WriteString(template.HTMLEscapeString(string(src[offset:])))
+
+	return template.HTML(builder.String())
+}
+
+func tokenClass(tok token.Token) string {
+	switch {
+	case tok == token.COMMENT:
+		return "comment"
+	case tok == token.STRING || tok == token.CHAR:
+		return "string"
+	case tok == token.INT || tok == token.FLOAT || tok == token.IMAG:
+		return "number"
+	case tok.IsKeyword():
+		return "keyword"
+	}
+	return ""
+}
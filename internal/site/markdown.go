@@ -0,0 +1,46 @@
+package site
+
+import (
+	"bytes"
+	"html/template"
+	"regexp"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+var converter = goldmark.New(
+	goldmark.WithExtensions(extension.Footnote, extension.Table),
+	// the puzzle descriptions use <pre> and <b> for code blocks
+	goldmark.WithRendererOptions(html.WithUnsafe()),
+)
+
+var (
+	preBlock    = regexp.MustCompile(`(?s)<pre>.*?</pre>\n?`)
+	fenceBlock  = regexp.MustCompile("(?s)```[^\n]*\n.*?```\n?")
+	archiveLink = regexp.MustCompile(`\]\((\.\./\.\./\d{4}/\d{2}/)README\.md\)`)
+)
+
+// Readme renders a puzzle description without its examples, links to other
+// puzzles in the archive are changed to link to their pages
+func Readme(markdown string) (template.HTML, error) {
+	markdown = RemoveExamples(markdown)
+	markdown = archiveLink.ReplaceAllString(markdown, "](${1}index.html)")
+	return Markdown(markdown)
+}
+
+// RemoveExamples removes the code blocks from a puzzle description, which are
+// the examples and their answers
+func RemoveExamples(markdown string) string {
+	markdown = preBlock.ReplaceAllString(markdown, "")
+	return fenceBlock.ReplaceAllString(markdown, "")
+}
+
+func Markdown(markdown string) (template.HTML, error) {
+	buffer := new(bytes.Buffer)
+	if err := converter.Convert([]byte(markdown), buffer); err != nil {
+		return "", err
+	}
+	return template.HTML(buffer.String()), nil
+}
@@ -0,0 +1,139 @@
+// Package site renders the archive of puzzles and solutions as a static HTML
+// site
+package site
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/microhod/adventofcode/internal/puzzle"
+)
+
+//go:embed templates/*.html templates/*.css
+var templates embed.FS
+
+type Year struct {
+	Year     int
+	Stars    int
+	MaxStars int
+	// Guessed is true when the stars are guessed rather than from the calendar
+	Guessed  bool
+	Calendar template.HTML
+	Days     []Day
+}
+
+type Day struct {
+	Year, Day int
+	Name      string
+	Stars     int
+	Readme    template.HTML
+	Source    template.HTML
+	Timings   []puzzle.Timing
+	// Imports are the internal packages used e.g. `geometry/plane`
+	Imports []string
+}
+
+func (d Day) Path() string {
+	return fmt.Sprintf("%d/%02d", d.Year, d.Day)
+}
+
+type Package struct {
+	// Path is relative to internal e.g. `geometry/plane`
+	Path  string
+	Files []File
+	Days  []Day
+}
+
+type File struct {
+	Name   string
+	Source template.HTML
+}
+
+// Site writes pages into the Out folder
+type Site struct {
+	Out  string
+	tmpl *template.Template
+}
+
+func New(out string) (*Site, error) {
+	tmpl, err := template.New("site").Funcs(template.FuncMap{
+		"stars":   func(n int) string { return strings.Repeat("*", n) },
+		"runtime": formatRuntime,
+	}).ParseFS(templates, "templates/*.html")
+	if err != nil {
+		return nil, err
+	}
+	return &Site{Out: out, tmpl: tmpl}, nil
+}
+
+func (s *Site) WriteIndex(years []Year) error {
+	if err := s.writeStyle(); err != nil {
+		return err
+	}
+	return s.write("index.html", "index", map[string]any{"Title": "Advent of Code", "Root": "", "Years": years})
+}
+
+func (s *Site) WriteYear(year Year) error {
+	return s.write(filepath.Join(fmt.Sprint(year.Year), "index.html"), "year", map[string]any{
+		"Title": fmt.Sprintf("Advent of Code %d", year.Year),
+		"Root":  "../",
+		"Year":  year,
+	})
+}
+
+func (s *Site) WriteDay(day Day) error {
+	return s.write(filepath.Join(day.Path(), "index.html"), "day", map[string]any{
+		"Title": fmt.Sprintf("%d Day %d: %s", day.Year, day.Day, day.Name),
+		"Root":  "../../",
+		"Day":   day,
+	})
+}
+
+func (s *Site) WritePackage(pkg Package) error {
+	root := strings.Repeat("../", strings.Count(pkg.Path, "/")+2)
+	return s.write(filepath.Join("internal", pkg.Path, "index.html"), "package", map[string]any{
+		"Title":   "internal/" + pkg.Path,
+		"Root":    root,
+		"Package": pkg,
+	})
+}
+
+func (s *Site) writeStyle() error {
+	style, err := templates.ReadFile("templates/style.css")
+	if err != nil {
+		return err
+	}
+	return s.writeFile("style.css", style)
+}
+
+func (s *Site) write(path, name string, data any) error {
+	builder := new(strings.Builder)
+	if err := s.tmpl.ExecuteTemplate(builder, name, data); err != nil {
+		return err
+	}
+	return s.writeFile(path, []byte(builder.String()))
+}
+
+func (s *Site) writeFile(path string, content []byte) error {
+	path = filepath.Join(s.Out, path)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+func formatRuntime(timings []puzzle.Timing) string {
+	var total time.Duration
+	for _, t := range timings {
+		total += t.Elapsed
+	}
+	if total < time.Millisecond {
+		return total.Round(time.Microsecond).String()
+	}
+	return total.Round(time.Millisecond).String()
+}
@@ -0,0 +1,165 @@
+// Package visual records frames of a simulation, so they can be saved as an
+// animated gif or played back in the terminal
+//
+// recording is off unless it's enabled e.g. by the -record flag of
+// puzzle.Solution.Run, so parts can call Record without slowing down
+package visual
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/microhod/adventofcode/internal/geometry/plane"
+)
+
+// Palette maps the values in a grid to colours, values without a colour are
+// drawn black
+type Palette[T comparable] map[T]color.Color
+
+// Frame is a snapshot of a grid, with each cell as an index into the palette
+type Frame struct {
+	Width, Height int
+	Cells         []uint8
+	Palette       color.Palette
+}
+
+var (
+	enabled bool
+	frames  []Frame
+)
+
+func Enable() {
+	enabled = true
+}
+
+func Enabled() bool {
+	return enabled
+}
+
+// Record adds a frame of the grid, if recording is enabled
+func Record[T comparable](g plane.Grid[T], palette Palette[T]) {
+	if !enabled {
+		return
+	}
+
+	frame := Frame{Width: g.Width, Height: g.Height, Cells: make([]uint8, 0, g.Width*g.Height)}
+	indexes := map[T]uint8{}
+	for _, value := range g.All() {
+		index, ok := indexes[value]
+		if !ok {
+			if len(frame.Palette) == 256 {
+				panic("record: gifs can only have 256 colours")
+			}
+			colour, ok := palette[value]
+			if !ok {
+				colour = color.Black
+			}
+			index = uint8(len(frame.Palette))
+			indexes[value] = index
+			frame.Palette = append(frame.Palette, colour)
+		}
+		frame.Cells = append(frame.Cells, index)
+	}
+	frames = append(frames, frame)
+}
+
+// Frames returns the frames recorded since the last Reset
+func Frames() []Frame {
+	return frames
+}
+
+func Reset() {
+	frames = nil
+}
+
+// WriteGIF writes the frames as an animated gif, each cell is scale x scale
+// pixels
+//
+// frames of different sizes are drawn in the top left of a canvas big enough
+// for all of them, with the rest filled black
+func WriteGIF(w io.Writer, frames []Frame, fps, scale int) error {
+	if len(frames) == 0 {
+		return fmt.Errorf("no frames to write")
+	}
+
+	var width, height int
+	for _, frame := range frames {
+		width, height = max(width, frame.Width), max(height, frame.Height)
+	}
+
+	animation := &gif.GIF{Config: image.Config{Width: width * scale, Height: height * scale}}
+	for _, frame := range frames {
+		// black is needed to fill the canvas, and an empty palette isn't valid
+		palette := frame.Palette
+		if (frame.Width < width || frame.Height < height || len(palette) == 0) && len(palette) < 256 {
+			palette = append(palette[:len(palette):len(palette)], color.Black)
+		}
+
+		img := image.NewPaletted(image.Rect(0, 0, width*scale, height*scale), palette)
+		if background := uint8(palette.Index(color.Black)); background != 0 {
+			for i := range img.Pix {
+				img.Pix[i] = background
+			}
+		}
+		for i, index := range frame.Cells {
+			x, y := i%frame.Width, i/frame.Width
+			for dy := range scale {
+				for dx := range scale {
+					img.SetColorIndex(x*scale+dx, y*scale+dy, index)
+				}
+			}
+		}
+		animation.Image = append(animation.Image, img)
+		// the delay is in 100ths of a second
+		animation.Delay = append(animation.Delay, max(1, 100/fps))
+	}
+	return gif.EncodeAll(w, animation)
+}
+
+// SaveGIF writes the frames as an animated gif to the file at path
+func SaveGIF(path string, frames []Frame, fps, scale int) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := WriteGIF(file, frames, fps, scale); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// Play draws the frames in the terminal one after another, each cell is two
+// characters wide so that it's roughly square
+func Play(w io.Writer, frames []Frame, fps int) {
+	delay := time.Second / time.Duration(fps)
+	for i, frame := range frames {
+		start := time.Now()
+
+		builder := new(strings.Builder)
+		// move to the top left and clear the screen
+		builder.WriteString("\033[H\033[2J")
+		for y := range frame.Height {
+			for x := range frame.Width {
+				r, g, b, _ := frame.Palette[frame.Cells[y*frame.Width+x]].RGBA()
+				fmt.Fprintf(builder, "\033[48;2;%d;%d;%dm  ", r>>8, g>>8, b>>8)
+			}
+			builder.WriteString("\033[0m\n")
+		}
+		fmt.Fprintf(builder, "frame %d/%d\n", i+1, len(frames))
+		io.WriteString(w, builder.String())
+
+		time.Sleep(delay - time.Since(start))
+	}
+}
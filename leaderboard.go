@@ -0,0 +1,120 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mgutz/ansi"
+	"github.com/microhod/adventofcode/internal/encoding/csv"
+	stat "github.com/microhod/adventofcode/internal/leaderboard"
+	"github.com/microhod/adventofcode/internal/puzzle"
+	"github.com/microhod/adventofcode/internal/set"
+)
+
+var (
+	goldStar   = ansi.ColorFunc("yellow+bh")("*")
+	silverStar = ansi.ColorFunc("blue+h")("*")
+	noStar     = ansi.ColorFunc("black+h")("*")
+)
+
+// leaderboard prints a private leaderboard in the same style as the site, or
+// statistics computed from it
+//
+// aoc leaderboard [-stats deltas|medians|scores|ranks] [-ignore DAYS] [-csv] YEAR ID
+func leaderboard(args []string) error {
+	flags := flag.NewFlagSet("leaderboard", flag.ExitOnError)
+	stats := flags.String("stats", "", "print statistics instead of the leaderboard: deltas, medians, scores or ranks")
+	ignore := flags.String("ignore", "", "comma separated days to ignore when recomputing scores")
+	asCSV := flags.Bool("csv", false, "write statistics as csv")
+	args = parseFlags(flags, args)
+
+	if len(args) < 2 {
+		return fmt.Errorf("need year and leaderboard id arguments")
+	}
+	year, err := strconv.Atoi(args[0])
+	if err != nil {
+		return err
+	}
+	id, err := strconv.Atoi(args[1])
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	board, err := client.Leaderboard(year, id)
+	if err != nil {
+		return err
+	}
+
+	if *stats == "" {
+		fmt.Print(renderLeaderboard(board, puzzle.Days(year)))
+		return nil
+	}
+
+	ignored, err := csv.ParseInts(*ignore)
+	if err != nil {
+		return err
+	}
+	scoring := stat.Scoring{Ignore: set.NewSet(ignored...)}
+
+	var table stat.Table
+	switch *stats {
+	case "deltas":
+		table, err = stat.DeltaTable(board)
+	case "medians":
+		table, err = stat.MedianTable(board)
+	case "scores":
+		table, err = stat.ScoreTable(board, scoring)
+	case "ranks":
+		table, err = stat.RankTable(board, scoring)
+	default:
+		return fmt.Errorf("unknown statistic: %s", *stats)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *asCSV {
+		return table.WriteCSV(os.Stdout)
+	}
+	return table.WriteText(os.Stdout)
+}
+
+func renderLeaderboard(board *puzzle.Leaderboard, days int) string {
+	builder := new(strings.Builder)
+
+	// day numbers are written vertically above the star columns
+	//                1111111111222222
+	//       1234567890123456789012345
+	indent := strings.Repeat(" ", 11)
+	var tens, units strings.Builder
+	for day := 1; day <= days; day++ {
+		tens.WriteByte(fmt.Sprintf("%2d", day)[0])
+		units.WriteString(fmt.Sprint(day % 10))
+	}
+	fmt.Fprintf(builder, "%s%s\n", indent, tens.String())
+	fmt.Fprintf(builder, "%s%s\n", indent, units.String())
+
+	for i, member := range board.Ranked() {
+		var stars strings.Builder
+		for day := 1; day <= days; day++ {
+			switch member.StarsOn(day) {
+			case 2:
+				stars.WriteString(goldStar)
+			case 1:
+				stars.WriteString(silverStar)
+			default:
+				stars.WriteString(noStar)
+			}
+		}
+		fmt.Fprintf(builder, "%3d) %5d %s %s\n", i+1, member.LocalScore, stars.String(), member.DisplayName())
+	}
+
+	return builder.String()
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"strconv"
@@ -16,81 +17,81 @@ const (
 	inputFile    = "input.txt"
 	testFile     = "test.txt"
 	solutionFile = "main.go"
+
+	testSolutionFile = "main_test.go"
+	testTemplate     = "main_test"
 )
 
-// get the puzzle files for the year & day specified
+var commands = map[string]func(args []string) error{
+	"calendar":    calendar,
+	"fetch":       fetch,
+	"index":       indexSolutions,
+	"inputs":      inputs,
+	"leaderboard": leaderboard,
+	"read":        read,
+	"readme":      readme,
+	"search":      searchPuzzles,
+	"site":        exportSite,
+	"stats":       stats,
+	"watch":       watch,
+}
+
+// aoc <command> [arguments]
+//
+// with no command, the arguments are passed to fetch e.g. `aoc 2024 1`
 func main() {
-	// parse arguments
-	if len(os.Args) < 3 {
-		fail(fmt.Errorf("need year and day arguments"))
+	if len(os.Args) < 2 {
+		fail(fmt.Errorf("need a command or year and day arguments"))
 	}
-	year, err := strconv.Atoi(os.Args[1])
-	if err != nil {
-		fail(err)
+
+	command, args := commands[os.Args[1]], os.Args[2:]
+	if command == nil {
+		command, args = fetch, os.Args[1:]
 	}
-	day, err := strconv.Atoi(os.Args[2])
-	if err != nil {
+
+	if err := command(args); err != nil {
 		fail(err)
 	}
+}
+
+// parseFlags parses flags which can appear before, after or in between the
+// positional arguments, which are returned
+func parseFlags(flags *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		flags.Parse(args)
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
 
-	// create client with token
+func newClient() (*puzzle.Client, error) {
 	bytes, err := os.ReadFile(tokenFile)
 	if err != nil {
-		fail(err)
+		return nil, err
 	}
 	token := strings.TrimSpace(string(bytes))
 
-	client := puzzle.NewClient(token)
-	p, err := client.Get(year, day)
-	if err != nil {
-		fail(err)
-	}
+	return puzzle.NewClient(token), nil
+}
 
-	// make folders
-	err = os.MkdirAll(folder(year, day), os.ModePerm)
-	if err != nil {
-		fail(err)
+func parseYearDay(args []string) (int, int, error) {
+	if len(args) < 2 {
+		return 0, 0, fmt.Errorf("need year and day arguments")
 	}
-
-	// README.md
-	readme, err := os.Create(fmt.Sprintf("%s/%s", folder(year, day), readmeFile))
+	year, err := strconv.Atoi(args[0])
 	if err != nil {
-		fail(err)
+		return 0, 0, err
 	}
-	fmt.Fprintln(readme, p.Readme)
-
-	// input.txt
-	input, err := os.Create(fmt.Sprintf("%s/%s", folder(year, day), inputFile))
+	day, err := strconv.Atoi(args[1])
 	if err != nil {
-		fail(err)
-	}
-	fmt.Fprint(input, p.Input)
-
-	// test.txt
-	testFilePath := fmt.Sprintf("%s/%s", folder(year, day), testFile)
-	// only create test.txt if it doesn't already exist
-	if !exists(testFilePath) {
-		test, err := os.Create(testFilePath)
-		if err != nil {
-			fail(err)
-		}
-		fmt.Fprintln(test, p.TestInput)
-	}
-
-	// main.go
-	solutionPath := fmt.Sprintf("%s/%s", folder(year, day), solutionFile)
-	// only create main.go if it doesn't already exist
-	if !exists(solutionPath) {
-		main, err := os.Create(solutionPath)
-		if err != nil {
-			fail(err)
-		}
-		solution, err := puzzle.InitialSolutionFile(p)
-		if err != nil {
-			fail(err)
-		}
-		fmt.Fprint(main, solution)
+		return 0, 0, err
 	}
+	return year, day, nil
 }
 
 func exists(path string) bool {
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/microhod/adventofcode/internal/puzzle"
+)
+
+// stars returns the number of stars earned on each day of year, as shown on
+// the calendar, or guessed from the day folders when there's no client or the
+// calendar can't be fetched, guessed is true for the latter
+func stars(client *puzzle.Client, year int) (dayStars map[int]int, guessed bool, err error) {
+	if client != nil {
+		cal, err := client.Calendar(year)
+		if err == nil {
+			return cal.Stars, false, nil
+		}
+		fmt.Fprintf(os.Stderr, "WARNING: %s, guessing the stars for %d\n", err, year)
+	}
+
+	dayStars = map[int]int{}
+	for _, day := range solvedDays(year) {
+		s, err := guessStars(year, day)
+		if err != nil {
+			return nil, true, err
+		}
+		dayStars[day] = s
+	}
+	return dayStars, true, nil
+}
+
+// guessStars works out the stars on a solved day from its folder: part 2
+// is only in the README once part 1 is solved, and there are only timings for
+// part 2 once it's written
+func guessStars(year, day int) (int, error) {
+	readme, err := os.ReadFile(filepath.Join(folder(year, day), readmeFile))
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	if strings.Contains(string(readme), "## Part 2") {
+		return 2, nil
+	}
+
+	timings, err := readTimings(year, day)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	for _, timing := range timings {
+		if timing.Part == 2 {
+			return 2, nil
+		}
+	}
+	return 1, nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/microhod/adventofcode/internal/markdown"
+)
+
+// read shows the puzzle description for the day in the terminal
+//
+// aoc read [-part N] [-width COLUMNS] YEAR DAY
+func read(args []string) error {
+	flags := flag.NewFlagSet("read", flag.ExitOnError)
+	part := flags.Int("part", 0, "only show this part of the puzzle")
+	width := flags.Int("width", terminalWidth(), "wrap paragraphs to this many columns")
+
+	year, day, err := parseYearDay(parseFlags(flags, args))
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(filepath.Join(folder(year, day), readmeFile))
+	if err != nil {
+		return err
+	}
+	text := string(content)
+
+	if *part != 0 {
+		if text, err = puzzlePart(text, *part); err != nil {
+			return err
+		}
+	}
+
+	fmt.Print(markdown.Terminal(text, *width))
+	return nil
+}
+
+// puzzlePart keeps the title and the section for the part
+func puzzlePart(readme string, part int) (string, error) {
+	title, body, _ := strings.Cut(readme, "\n")
+	part1, part2, found := strings.Cut(body, "## Part 2")
+
+	switch {
+	case part == 1:
+		return title + "\n" + part1, nil
+	case part == 2 && found:
+		return title + "\n\n## Part 2" + part2, nil
+	case part == 2:
+		return "", fmt.Errorf("part 2 isn't in %s yet, fetch it again once part 1 is solved", readmeFile)
+	default:
+		return "", fmt.Errorf("part must be 1 or 2")
+	}
+}
+
+// terminalWidth uses $COLUMNS if it's set, capped so that paragraphs stay
+// readable
+func terminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return min(columns, 100)
+	}
+	return 80
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/microhod/adventofcode/internal/puzzle"
+)
+
+const (
+	badgesRegion   = "badges"
+	progressRegion = "progress"
+)
+
+// readme rewrites the progress badges and tables in the root README.md, only
+// the content between the region markers is changed e.g.
+//
+//	<!-- aoc:badges -->
+//	...
+//	<!-- /aoc:badges -->
+//
+// without a token the stars are guessed from the day folders, see guessStars
+//
+// aoc readme
+func readme(args []string) error {
+	client, err := newClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: %s, guessing the stars from the day folders\n", err)
+		client = nil
+	}
+
+	all, err := years()
+	if err != nil {
+		return err
+	}
+	sort.Ints(all)
+
+	var badges, tables []string
+	for _, year := range all {
+		dayStars, guessed, err := stars(client, year)
+		if err != nil {
+			return err
+		}
+
+		badges = append(badges, badge(year, dayStars, guessed))
+
+		table, err := progressTable(year, dayStars, guessed)
+		if err != nil {
+			return err
+		}
+		tables = append(tables, table)
+	}
+
+	bytes, err := os.ReadFile(readmeFile)
+	if err != nil {
+		return err
+	}
+	content := string(bytes)
+	content = replaceRegion(content, badgesRegion, strings.Join(badges, "\n"))
+	content = replaceRegion(content, progressRegion, strings.Join(tables, "\n\n"))
+
+	return os.WriteFile(readmeFile, []byte(content), 0644)
+}
+
+// badge shows the total stars for year, prefixed with ~ when they're guessed
+func badge(year int, dayStars map[int]int, guessed bool) string {
+	var total int
+	for _, s := range dayStars {
+		total += s
+	}
+	max := 2 * puzzle.Days(year)
+
+	colour := "yellow"
+	if total == max {
+		colour = "brightgreen"
+	}
+
+	estimate := ""
+	if guessed {
+		estimate = "~"
+	}
+
+	return fmt.Sprintf("![](https://img.shields.io/badge/%d%%20⭐-%s%d/%d-%s)", year, estimate, total, max, colour)
+}
+
+func progressTable(year int, dayStars map[int]int, guessed bool) (string, error) {
+	builder := new(strings.Builder)
+	fmt.Fprintf(builder, "### %d\n\n", year)
+	if guessed {
+		fmt.Fprint(builder, "_stars are estimated from the day folders_\n\n")
+	}
+	fmt.Fprintln(builder, "| Day | Puzzle | Stars | Runtime |")
+	fmt.Fprint(builder, "| --- | --- | --- | --- |")
+
+	for day := 1; day <= puzzle.Days(year); day++ {
+		solved := exists(filepath.Join(folder(year, day), solutionFile))
+		if !solved && dayStars[day] == 0 {
+			continue
+		}
+
+		var name, runtime string
+		if solved {
+			var err error
+			if name, err = solutionName(year, day); err != nil {
+				return "", err
+			}
+
+			timings, err := readTimings(year, day)
+			if err != nil && !os.IsNotExist(err) {
+				return "", err
+			}
+			runtime = formatRuntime(timings)
+		}
+
+		fmt.Fprintf(builder, "\n| [%d](%s) | %s | %s | %s |",
+			day, folder(year, day), name, strings.Repeat("⭐", dayStars[day]), runtime)
+	}
+	return builder.String(), nil
+}
+
+func formatRuntime(timings []puzzle.Timing) string {
+	if len(timings) == 0 {
+		return "-"
+	}
+
+	var total time.Duration
+	for _, t := range timings {
+		total += t.Elapsed
+	}
+	if total < time.Millisecond {
+		return total.Round(time.Microsecond).String()
+	}
+	return total.Round(time.Millisecond).String()
+}
+
+// replaceRegion replaces the content between the markers for region, if the
+// markers don't exist then the region is added to the end of the content
+func replaceRegion(content, region, replacement string) string {
+	start := fmt.Sprintf("<!-- aoc:%s -->", region)
+	end := fmt.Sprintf("<!-- /aoc:%s -->", region)
+	block := fmt.Sprintf("%s\n%s\n%s", start, replacement, end)
+
+	i := strings.Index(content, start)
+	j := strings.Index(content, end)
+	if i < 0 || j < i {
+		return strings.TrimRight(content, "\n") + "\n\n" + block + "\n"
+	}
+	return content[:i] + block + content[j+len(end):]
+}
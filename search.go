@@ -0,0 +1,128 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/microhod/adventofcode/internal/puzzle"
+	"github.com/microhod/adventofcode/internal/search"
+)
+
+// search finds puzzles whose descriptions match the query, ranked with BM25,
+// the index is saved in the cache folder until one of the READMEs changes
+//
+// aoc search [-fuzzy] [-n RESULTS] QUERY...
+func searchPuzzles(args []string) error {
+	flags := flag.NewFlagSet("search", flag.ExitOnError)
+	fuzzy := flags.Bool("fuzzy", false, "also match words which are spelled slightly differently")
+	n := flags.Int("n", 10, "the maximum number of results")
+	width := flags.Int("width", 120, "the width of the snippet shown for each result")
+
+	query := strings.Join(parseFlags(flags, args), " ")
+	if query == "" {
+		return fmt.Errorf("need a query")
+	}
+	if *n <= 0 {
+		return fmt.Errorf("-n must be positive, got: %d", *n)
+	}
+
+	index, err := searchIndex()
+	if err != nil {
+		return err
+	}
+
+	results := index.Search(query, *fuzzy)
+	if len(results) == 0 {
+		fmt.Println("no results")
+		return nil
+	}
+	for _, result := range results[:min(*n, len(results))] {
+		doc := result.Document
+		fmt.Printf("%s %s %s\n", puzzle.BoldGreen(folder(doc.Year, doc.Day)), doc.Name, locked(fmt.Sprintf("(%.2f)", result.Score)))
+		fmt.Printf("    %s\n", search.Snippet(doc.Text, result.Terms, *width))
+	}
+	return nil
+}
+
+// searchIndex loads the index saved in the cache, or builds and saves it if
+// any of the READMEs have changed since
+func searchIndex() (*search.Index, error) {
+	found, err := findReadmes()
+	if err != nil {
+		return nil, err
+	}
+
+	// the key is the path, modification time and size of every README
+	hash := sha256.New()
+	for _, r := range found {
+		info, err := os.Stat(r.path)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(hash, "%s %d %d\n", r.path, info.ModTime().UnixNano(), info.Size())
+	}
+	key := hex.EncodeToString(hash.Sum(nil))
+
+	cached := filepath.Join(puzzle.DefaultCacheDir(), "search.gob")
+	if index, ok := search.Load(cached, key); ok {
+		return index, nil
+	}
+
+	docs, err := readmes(found)
+	if err != nil {
+		return nil, err
+	}
+	index := search.NewIndex(docs)
+	if err := index.Save(cached, key); err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: couldn't save the search index: %s\n", err)
+	}
+	return index, nil
+}
+
+// readmePath is where the puzzle description for a day is
+type readmePath struct {
+	year, day int
+	path      string
+}
+
+// findReadmes finds the puzzle description in every day folder
+func findReadmes() ([]readmePath, error) {
+	all, err := years()
+	if err != nil {
+		return nil, err
+	}
+	sort.Ints(all)
+
+	var found []readmePath
+	for _, year := range all {
+		for day := 1; day <= puzzle.Days(year); day++ {
+			if path := filepath.Join(folder(year, day), readmeFile); exists(path) {
+				found = append(found, readmePath{year: year, day: day, path: path})
+			}
+		}
+	}
+	return found, nil
+}
+
+// readmes reads the puzzle descriptions
+func readmes(found []readmePath) ([]search.Document, error) {
+	var docs []search.Document
+	for _, r := range found {
+		content, err := os.ReadFile(r.path)
+		if err != nil {
+			return nil, err
+		}
+
+		// the first line is the title e.g. `# Day 22: Monkey Map`
+		title, text, _ := strings.Cut(string(content), "\n")
+		_, name, _ := strings.Cut(title, ": ")
+		docs = append(docs, search.Document{Year: r.year, Day: r.day, Name: strings.TrimSpace(name), Text: strings.TrimSpace(text)})
+	}
+	return docs, nil
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/microhod/adventofcode/internal/puzzle"
+	"github.com/microhod/adventofcode/internal/site"
+)
+
+// exportSite writes the archive as a static HTML site: an index for each year
+// with its calendar, and a page for each day with the puzzle, the solution and
+// its timings, the inputs are never included
+//
+// without a token, or when a calendar can't be fetched, the calendar is left
+// out and the stars are guessed from the READMEs and timings
+//
+// aoc site [-out DIR]
+func exportSite(args []string) error {
+	flags := flag.NewFlagSet("site", flag.ExitOnError)
+	out := flags.String("out", "site", "the folder to write the site to")
+	parseFlags(flags, args)
+
+	s, err := site.New(*out)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: %s, the calendars will be left out\n", err)
+		client = nil
+	}
+
+	all, err := years()
+	if err != nil {
+		return err
+	}
+	sort.Ints(all)
+
+	var siteYears []site.Year
+	packages := map[string]*site.Package{}
+	for _, year := range all {
+		siteYear, err := yearPage(client, year)
+		if err != nil {
+			return err
+		}
+
+		for _, day := range siteYear.Days {
+			if err := s.WriteDay(day); err != nil {
+				return err
+			}
+			for _, path := range day.Imports {
+				if packages[path] == nil {
+					packages[path] = &site.Package{Path: path}
+				}
+				packages[path].Days = append(packages[path].Days, day)
+			}
+		}
+		if err := s.WriteYear(siteYear); err != nil {
+			return err
+		}
+		siteYears = append(siteYears, siteYear)
+	}
+
+	for _, pkg := range packages {
+		if pkg.Files, err = packageFiles(filepath.Join("internal", pkg.Path)); err != nil {
+			return err
+		}
+		if err := s.WritePackage(*pkg); err != nil {
+			return err
+		}
+	}
+
+	if err := s.WriteIndex(siteYears); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %d years to %s\n", len(siteYears), *out)
+	return nil
+}
+
+func yearPage(client *puzzle.Client, year int) (site.Year, error) {
+	siteYear := site.Year{Year: year, MaxStars: 2 * puzzle.Days(year)}
+
+	var dayStars map[int]int
+	var cal *puzzle.Calendar
+	if client != nil {
+		var err error
+		if cal, err = client.Calendar(year); err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: %s, leaving out the calendar for %d and guessing the stars\n", err, year)
+			cal = nil
+		} else {
+			dayStars = cal.Stars
+		}
+	}
+
+	linked := map[int]bool{}
+	for _, day := range solvedDays(year) {
+		siteDay, err := dayPage(year, day)
+		if err != nil {
+			return siteYear, err
+		}
+		if dayStars != nil {
+			siteDay.Stars = dayStars[day]
+		}
+		siteYear.Stars += siteDay.Stars
+		siteYear.Days = append(siteYear.Days, siteDay)
+		linked[day] = true
+	}
+
+	if cal != nil {
+		siteYear.Stars = cal.TotalStars()
+		siteYear.Calendar = site.Calendar(cal, linked)
+	} else {
+		siteYear.Guessed = true
+	}
+	return siteYear, nil
+}
+
+func dayPage(year, day int) (site.Day, error) {
+	siteDay := site.Day{Year: year, Day: day}
+
+	var err error
+	if siteDay.Name, err = solutionName(year, day); err != nil {
+		return siteDay, err
+	}
+
+	readme, err := os.ReadFile(filepath.Join(folder(year, day), readmeFile))
+	if err != nil && !os.IsNotExist(err) {
+		return siteDay, err
+	}
+	if siteDay.Readme, err = site.Readme(string(readme)); err != nil {
+		return siteDay, err
+	}
+	// a guess for when there's no calendar
+	if siteDay.Stars, err = guessStars(year, day); err != nil {
+		return siteDay, err
+	}
+
+	source, err := os.ReadFile(filepath.Join(folder(year, day), solutionFile))
+	if err != nil {
+		return siteDay, err
+	}
+	siteDay.Source = site.HighlightGo(source)
+
+	if siteDay.Timings, err = readTimings(year, day); err != nil && !os.IsNotExist(err) {
+		return siteDay, err
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", source, parser.ImportsOnly)
+	if err != nil {
+		return siteDay, err
+	}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if internal, ok := strings.CutPrefix(path, module+"/internal/"); ok {
+			siteDay.Imports = append(siteDay.Imports, internal)
+		}
+	}
+	return siteDay, nil
+}
+
+// packageFiles highlights the source of the package, without its tests
+func packageFiles(dir string) ([]site.File, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []site.File
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		source, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, site.File{Name: filepath.Base(path), Source: site.HighlightGo(source)})
+	}
+	return files, nil
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/microhod/adventofcode/internal/puzzle"
+)
+
+const timingsFile = "timings.json"
+
+// solvedDays returns the days in year which have a solution
+func solvedDays(year int) []int {
+	var days []int
+	for day := 1; day <= puzzle.Days(year); day++ {
+		if exists(filepath.Join(folder(year, day), solutionFile)) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// runSolution runs the solution for the day from inside its folder, so that
+// relative paths to input files resolve
+//
+// the solution is built first rather than using `go run`, so that cancelling
+// ctx stops the solution itself
+func runSolution(ctx context.Context, year, day int, stdout, stderr io.Writer, args ...string) error {
+	binary, cleanup, err := buildSolution(ctx, year, day, stderr)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	return runBinary(ctx, binary, year, day, stdout, stderr, args...)
+}
+
+// buildSolution builds the solution for the day into a temporary folder, which
+// is removed by cleanup
+func buildSolution(ctx context.Context, year, day int, stderr io.Writer) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "aoc")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	binary := filepath.Join(dir, "solution")
+	build := exec.CommandContext(ctx, "go", "build", "-o", binary, ".")
+	build.Dir = folder(year, day)
+	build.Stderr = stderr
+	if err := build.Run(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("building %s: %w", folder(year, day), err)
+	}
+	return binary, cleanup, nil
+}
+
+func runBinary(ctx context.Context, binary string, year, day int, stdout, stderr io.Writer, args ...string) error {
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Dir = folder(year, day)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("running %s: %w", folder(year, day), err)
+	}
+	return nil
+}
+
+// recordTimings runs the solution and stores the time taken by each part,
+// solutions which take longer than timeout are stopped
+func recordTimings(year, day int, timeout time.Duration) ([]puzzle.Timing, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := runSolution(ctx, year, day, io.Discard, io.Discard, "-timings", timingsFile)
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("running %s: timed out after %s", folder(year, day), timeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return readTimings(year, day)
+}
+
+// readTimings reads the timings stored by the last recordTimings
+func readTimings(year, day int) ([]puzzle.Timing, error) {
+	return puzzle.ReadTimings(filepath.Join(folder(year, day), timingsFile))
+}
+
+// linesOfCode counts the lines in the solution which aren't blank or comments
+func linesOfCode(year, day int) (int, error) {
+	file, err := os.Open(filepath.Join(folder(year, day), solutionFile))
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	var count int
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+		count++
+	}
+	return count, scanner.Err()
+}
+
+var solutionNameRegex = regexp.MustCompile(`NewSolution\(\s*"([^"]*)"`)
+
+// solutionName gets the puzzle name passed to puzzle.NewSolution
+func solutionName(year, day int) (string, error) {
+	bytes, err := os.ReadFile(filepath.Join(folder(year, day), solutionFile))
+	if err != nil {
+		return "", err
+	}
+
+	match := solutionNameRegex.FindSubmatch(bytes)
+	if match == nil {
+		return "", nil
+	}
+	return string(match[1]), nil
+}
+
+// years returns the years which have a folder in the repo
+func years() ([]int, error) {
+	entries, err := os.ReadDir(".")
+	if err != nil {
+		return nil, err
+	}
+
+	var years []int
+	for _, entry := range entries {
+		if !entry.IsDir() || len(entry.Name()) != 4 {
+			continue
+		}
+		if year, err := strconv.Atoi(entry.Name()); err == nil {
+			years = append(years, year)
+		}
+	}
+	return years, nil
+}
+
+func parseYear(args []string) (int, error) {
+	if len(args) < 1 {
+		return 0, fmt.Errorf("need year argument")
+	}
+	return strconv.Atoi(args[0])
+}
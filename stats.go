@@ -0,0 +1,144 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/microhod/adventofcode/internal/puzzle"
+)
+
+type dayStats struct {
+	day     int
+	site    puzzle.DayStats
+	timings []puzzle.Timing
+	lines   int
+}
+
+// written is the time taken to get the last star, days over 24 hours sort last
+func (s dayStats) written() time.Duration {
+	last := s.site.Part1
+	if s.site.Part2.Done() {
+		last = s.site.Part2
+	}
+	if last.Over24h {
+		return 24 * time.Hour
+	}
+	return last.Time
+}
+
+func (s dayStats) runtime() time.Duration {
+	var total time.Duration
+	for _, t := range s.timings {
+		total += t.Elapsed
+	}
+	return total
+}
+
+// stats prints the local run time and size of each solution, with -personal
+// this is merged with the times and ranks from the personal stats page
+//
+// aoc stats [-personal] [-run] [-timeout DURATION] [-sort day|write|run|loc] YEAR
+func stats(args []string) error {
+	flags := flag.NewFlagSet("stats", flag.ExitOnError)
+	personal := flags.Bool("personal", false, "include the time, rank and score from adventofcode.com")
+	run := flags.Bool("run", false, "rerun each solution to record timings")
+	timeout := flags.Duration("timeout", time.Minute, "stop running a solution after this long")
+	sortBy := flags.String("sort", "day", "sort by day, write, run or loc")
+	year, err := parseYear(parseFlags(flags, args))
+	if err != nil {
+		return err
+	}
+
+	rows := map[int]*dayStats{}
+	for _, day := range solvedDays(year) {
+		row := &dayStats{day: day}
+		if row.lines, err = linesOfCode(year, day); err != nil {
+			return err
+		}
+
+		if *run {
+			fmt.Fprintf(os.Stderr, "running %s\n", folder(year, day))
+			if row.timings, err = recordTimings(year, day, *timeout); err != nil {
+				fmt.Fprintf(os.Stderr, "WARNING: %s\n", err)
+			}
+		} else {
+			row.timings, err = readTimings(year, day)
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		rows[day] = row
+	}
+
+	if *personal {
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		site, err := client.PersonalStats(year)
+		if err != nil {
+			return err
+		}
+		for _, s := range site {
+			if rows[s.Day] == nil {
+				rows[s.Day] = &dayStats{day: s.Day}
+			}
+			rows[s.Day].site = s
+		}
+	}
+
+	var sorted []dayStats
+	for _, row := range rows {
+		sorted = append(sorted, *row)
+	}
+	less := map[string]func(a, b dayStats) bool{
+		"day":   func(a, b dayStats) bool { return a.day < b.day },
+		"write": func(a, b dayStats) bool { return a.written() > b.written() },
+		"run":   func(a, b dayStats) bool { return a.runtime() > b.runtime() },
+		"loc":   func(a, b dayStats) bool { return a.lines > b.lines },
+	}[*sortBy]
+	if less == nil {
+		return fmt.Errorf("unknown sort: %s", *sortBy)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return less(sorted[i], sorted[j]) })
+
+	return writeStats(sorted, *personal)
+}
+
+func writeStats(rows []dayStats, personal bool) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	header := []string{"day"}
+	if personal {
+		header = append(header, "time 1", "rank 1", "score 1", "time 2", "rank 2", "score 2")
+	}
+	header = append(header, "part 1", "part 2", "loc")
+	fmt.Fprintln(tw, strings.Join(header, "\t")+"\t")
+
+	for _, row := range rows {
+		cells := []string{fmt.Sprint(row.day)}
+		if personal {
+			for _, part := range []puzzle.PartStats{row.site.Part1, row.site.Part2} {
+				cells = append(cells, part.String(), fmt.Sprint(part.Rank), fmt.Sprint(part.Score))
+			}
+		}
+		// with -part only some parts have timings
+		for _, part := range []int{1, 2} {
+			cell := "-"
+			for _, t := range row.timings {
+				if t.Part == part {
+					cell = t.Elapsed.Round(time.Microsecond).String()
+				}
+			}
+			cells = append(cells, cell)
+		}
+		cells = append(cells, fmt.Sprint(row.lines))
+		fmt.Fprintln(tw, strings.Join(cells, "\t")+"\t")
+	}
+	return tw.Flush()
+}
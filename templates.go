@@ -0,0 +1,34 @@
+package main
+
+import (
+	"embed"
+	"io/fs"
+	"os"
+
+	"github.com/microhod/adventofcode/internal/config"
+)
+
+//go:embed templates/*.go.tmpl
+var builtinTemplates embed.FS
+
+// templateFilesystems are searched in order for solution templates: the
+// folder in the user's config, the templates folder in the repo and finally
+// the templates built into aoc
+func templateFilesystems() ([]fs.FS, error) {
+	c, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var filesystems []fs.FS
+	if c.Templates != "" {
+		filesystems = append(filesystems, os.DirFS(c.Templates))
+	}
+	filesystems = append(filesystems, os.DirFS("templates"))
+
+	builtin, err := fs.Sub(builtinTemplates, "templates")
+	if err != nil {
+		return nil, err
+	}
+	return append(filesystems, builtin), nil
+}
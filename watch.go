@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/microhod/adventofcode/internal/puzzle"
+)
+
+const clearScreen = "\033[H\033[2J"
+
+// watch reruns a part of a solution whenever the day folder or internal/
+// changes, first against the example and then the real input
+//
+// only parts created with puzzle.Answer can be run against the example, other
+// parts always read the input they were written for so only that is run
+//
+// aoc watch [-part N] [-interval DURATION] [-debounce DURATION] [-timeout DURATION] YEAR DAY
+func watch(args []string) error {
+	flags := flag.NewFlagSet("watch", flag.ExitOnError)
+	part := flags.Int("part", 1, "the part to run")
+	interval := flags.Duration("interval", 500*time.Millisecond, "how often to check for changes")
+	debounce := flags.Duration("debounce", 300*time.Millisecond, "wait until there are no changes for this long before running")
+	timeout := flags.Duration("timeout", time.Minute, "stop running the solution after this long")
+
+	year, day, err := parseYearDay(parseFlags(flags, args))
+	if err != nil {
+		return err
+	}
+	dirs := []string{folder(year, day), "internal"}
+
+	w := &watcher{year: year, day: day, part: *part, timeout: *timeout, previous: map[string]string{}}
+	last := snapshot(dirs)
+	for {
+		w.run()
+
+		// wait for a change, then for the changes to settle
+		for {
+			time.Sleep(*interval)
+			if current := snapshot(dirs); !maps.Equal(current, last) {
+				last = current
+				break
+			}
+		}
+		for {
+			time.Sleep(*debounce)
+			current := snapshot(dirs)
+			if maps.Equal(current, last) {
+				break
+			}
+			last = current
+		}
+	}
+}
+
+type watcher struct {
+	year, day, part int
+	timeout         time.Duration
+	// previous is the output from the last run against each input
+	previous map[string]string
+}
+
+func (w *watcher) run() {
+	fmt.Print(clearScreen)
+	fmt.Printf("%s part %d %s\n\n", folder(w.year, w.day), w.part, time.Now().Format(time.TimeOnly))
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	defer cancel()
+
+	stderr := new(bytes.Buffer)
+	binary, cleanup, err := buildSolution(ctx, w.year, w.day, stderr)
+	if err != nil {
+		fmt.Println(puzzle.BoldRed(err.Error()))
+		fmt.Println(stderr.String())
+		return
+	}
+	defer cleanup()
+
+	inputs := []string{inputFile}
+	if usesAnswer(w.year, w.day, w.part) {
+		inputs = w.inputs()
+	} else {
+		fmt.Printf("part %d reads its own input, wrap it in puzzle.Answer to check the example first\n\n", w.part)
+	}
+
+	for _, input := range inputs {
+		fmt.Println(puzzle.BoldGreen(input))
+
+		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+		start := time.Now()
+		err := runBinary(ctx, binary, w.year, w.day, stdout, stderr, "-part", fmt.Sprint(w.part), "-input", input)
+		elapsed := time.Since(start)
+
+		output := strings.TrimSpace(stdout.String())
+		fmt.Println(output)
+		if err != nil {
+			fmt.Println(puzzle.BoldRed(err.Error()))
+			fmt.Println(lastLines(stderr.String(), 10))
+		}
+		fmt.Printf("⏰ %s\n", elapsed.Round(time.Microsecond))
+
+		if previous, ok := w.previous[input]; ok && previous != output {
+			fmt.Println("\nchanged since the last run:")
+			fmt.Println(diff(previous, output))
+		}
+		w.previous[input] = output
+		fmt.Println()
+
+		// don't bother with the real input if the example fails
+		if err != nil {
+			return
+		}
+	}
+}
+
+// inputs are the example for the part followed by the real input
+func (w *watcher) inputs() []string {
+	var inputs []string
+	for _, example := range []string{fmt.Sprintf("test%d.txt", w.part), testFile} {
+		if exists(filepath.Join(folder(w.year, w.day), example)) {
+			inputs = append(inputs, example)
+			break
+		}
+	}
+	return append(inputs, inputFile)
+}
+
+// usesAnswer is true if the part is created with puzzle.Answer in the call to
+// puzzle.NewSolution, so it reads the file passed with -input
+func usesAnswer(year, day, part int) bool {
+	file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(folder(year, day), solutionFile), nil, 0)
+	if err != nil {
+		return false
+	}
+
+	isPuzzle := func(expr ast.Expr, name string) bool {
+		selector, ok := expr.(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+		pkg, ok := selector.X.(*ast.Ident)
+		return ok && pkg.Name == "puzzle" && selector.Sel.Name == name
+	}
+
+	var answer bool
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		// the first argument is the name of the puzzle
+		if !ok || !isPuzzle(call.Fun, "NewSolution") || part < 1 || part >= len(call.Args) {
+			return true
+		}
+		arg, ok := call.Args[part].(*ast.CallExpr)
+		answer = ok && isPuzzle(arg.Fun, "Answer")
+		return false
+	})
+	return answer
+}
+
+// snapshot records the modification time and size of every file in dirs
+func snapshot(dirs []string) map[string]string {
+	files := map[string]string{}
+	for _, dir := range dirs {
+		filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return nil
+			}
+			if info, err := entry.Info(); err == nil {
+				files[path] = fmt.Sprintf("%d %d", info.ModTime().UnixNano(), info.Size())
+			}
+			return nil
+		})
+	}
+	return files
+}
+
+// diff compares the outputs line by line
+func diff(before, after string) string {
+	beforeLines, afterLines := strings.Split(before, "\n"), strings.Split(after, "\n")
+
+	var lines []string
+	for i := range max(len(beforeLines), len(afterLines)) {
+		var b, a string
+		if i < len(beforeLines) {
+			b = beforeLines[i]
+		}
+		if i < len(afterLines) {
+			a = afterLines[i]
+		}
+		if a == b {
+			continue
+		}
+		if i < len(beforeLines) {
+			lines = append(lines, puzzle.BoldRed("- "+b))
+		}
+		if i < len(afterLines) {
+			lines = append(lines, puzzle.BoldGreen("+ "+a))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}